@@ -2,8 +2,10 @@ package bandwidth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -19,49 +21,206 @@ func (c *Client) CreatePeer(ctx context.Context, applicationID, siteID, peerName
 	if err != nil {
 		return "", err
 	}
-	splitted := strings.Split(headers.Get("Location"), "/sites/"+siteID+"/sippeers/")
-	if len(splitted) != 2 {
+	id := idFromLocation(headers, "/sites/"+siteID+"/sippeers/")
+	if id == "" {
 		return "", fmt.Errorf("unknown peer ID: %v", headers.Get("Location"))
 	}
-	return splitted[1], nil
+	return id, nil
+}
+
+// idFromLocation returns the ID of the created entity from the Location header.
+func idFromLocation(headers http.Header, prefix string) string {
+	splitted := strings.Split(headers.Get("Location"), prefix)
+	if len(splitted) != 2 {
+		return ""
+	}
+	return splitted[1]
+}
+
+// CreateSite creates the site (aka sub-account) and returns its ID.
+func (c *Client) CreateSite(ctx context.Context, site *Site) (string, error) {
+	path := c.AccountsEndpoint + "/sites"
+	req := *site
+	req.ID = ""
+	_, headers, err := c.makeAccountsRequest(ctx, http.MethodPost, path, nil, &req)
+	if err != nil {
+		return "", err
+	}
+	id := idFromLocation(headers, "/sites/")
+	if id == "" {
+		return "", fmt.Errorf("unknown site ID: %v", headers.Get("Location"))
+	}
+	return id, nil
+}
+
+// GetSite returns the site.
+func (c *Client) GetSite(ctx context.Context, siteID string) (*Site, error) {
+	path := c.AccountsEndpoint + "/sites/" + siteID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &SiteResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*SiteResponse).Site, nil
+}
+
+// ListSites returns all sites of the account.
+func (c *Client) ListSites(ctx context.Context) ([]Site, error) {
+	path := c.AccountsEndpoint + "/sites"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &SitesResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*SitesResponse).Sites, nil
+}
+
+// UpdateSite updates the site.
+func (c *Client) UpdateSite(ctx context.Context, siteID string, site *Site) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID
+	req := *site
+	req.ID = ""
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodPut, path, nil, &req)
+	return err
+}
+
+// DeleteSite deletes the site. The site must not have any sip-peers.
+func (c *Client) DeleteSite(ctx context.Context, siteID string) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// GetPeer returns the sip-peer (aka location) of the site.
+func (c *Client) GetPeer(ctx context.Context, siteID, peerID string) (*SipPeer, error) {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &SipPeerResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*SipPeerResponse).SipPeer, nil
+}
+
+// ListPeers returns all sip-peers of the site.
+func (c *Client) ListPeers(ctx context.Context, siteID string) ([]SipPeer, error) {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &SipPeersResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*SipPeersResponse).SipPeers, nil
 }
 
-// EnableSMS enables SMS
+// UpdatePeer updates the sip-peer settings.
+func (c *Client) UpdatePeer(ctx context.Context, siteID, peerID string, peer *SipPeer) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID
+	req := *peer
+	req.PeerID = ""
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodPut, path, nil, &req)
+	return err
+}
+
+// DeletePeer deletes the sip-peer. The peer must not have any numbers.
+func (c *Client) DeletePeer(ctx context.Context, siteID, peerID string) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// DefaultSMSFeatureSettings are the SMS settings used by EnableSMS.
+var DefaultSMSFeatureSettings = SipPeerSmsFeatureSettings{
+	TollFree:    true,
+	ShortCode:   false,
+	Protocol:    "HTTP",
+	Zone1:       true,
+	A2pLongCode: "DefaultOff",
+}
+
+// EnableSMS enables SMS with DefaultSMSFeatureSettings.
 func (c *Client) EnableSMS(ctx context.Context, siteID, peerID string) (*SipPeerSmsFeatureResponse, error) {
+	return c.EnableSMSFeature(ctx, siteID, peerID, &SipPeerSmsFeature{SipPeerSmsFeatureSettings: DefaultSMSFeatureSettings})
+}
+
+// EnableSMSFeature enables SMS with the given settings.
+func (c *Client) EnableSMSFeature(ctx context.Context, siteID, peerID string, feature *SipPeerSmsFeature) (*SipPeerSmsFeatureResponse, error) {
+	return c.setSMSFeature(ctx, http.MethodPost, siteID, peerID, feature)
+}
+
+// UpdateSMSFeature replaces the SMS settings of the enabled feature.
+func (c *Client) UpdateSMSFeature(ctx context.Context, siteID, peerID string, feature *SipPeerSmsFeature) (*SipPeerSmsFeatureResponse, error) {
+	return c.setSMSFeature(ctx, http.MethodPut, siteID, peerID, feature)
+}
+
+func (c *Client) setSMSFeature(ctx context.Context, method, siteID, peerID string, feature *SipPeerSmsFeature) (*SipPeerSmsFeatureResponse, error) {
 	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/sms"
-	feature := SipPeerSmsFeature{
-		SipPeerSmsFeatureSettings: SipPeerSmsFeatureSettings{
-			TollFree:    true,
-			ShortCode:   false,
-			Protocol:    "HTTP",
-			Zone1:       true,
-			A2pLongCode: "DefaultOff",
-		},
+	result, _, err := c.makeAccountsRequest(ctx, method, path, &SipPeerSmsFeatureResponse{}, feature)
+	if err != nil {
+		return nil, err
 	}
+	return result.(*SipPeerSmsFeatureResponse), nil
+}
 
-	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &SipPeerSmsFeatureResponse{}, &feature)
+// GetSMSFeature returns the SMS settings of the sip-peer.
+func (c *Client) GetSMSFeature(ctx context.Context, siteID, peerID string) (*SipPeerSmsFeatureResponse, error) {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/sms"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &SipPeerSmsFeatureResponse{})
 	if err != nil {
 		return nil, err
 	}
 	return result.(*SipPeerSmsFeatureResponse), nil
 }
 
-// EnableMMS enables MMS
+// DisableSMS disables SMS on the sip-peer.
+func (c *Client) DisableSMS(ctx context.Context, siteID, peerID string) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/sms"
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// EnableMMS enables MMS over HTTP.
 func (c *Client) EnableMMS(ctx context.Context, siteID, peerID string) (*MmsFeatureResponse, error) {
-	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/mms"
 	feature := MmsFeature{
 		MmsSettings: MmsSettings{
 			Protocol: "HTTP",
 		},
 	}
+	return c.EnableMMSFeature(ctx, siteID, peerID, &feature)
+}
+
+// EnableMMSFeature enables MMS with the given settings.
+func (c *Client) EnableMMSFeature(ctx context.Context, siteID, peerID string, feature *MmsFeature) (*MmsFeatureResponse, error) {
+	return c.setMMSFeature(ctx, http.MethodPost, siteID, peerID, feature)
+}
+
+// UpdateMMSFeature replaces the MMS settings of the enabled feature (e.g. to change the protocol).
+func (c *Client) UpdateMMSFeature(ctx context.Context, siteID, peerID string, feature *MmsFeature) (*MmsFeatureResponse, error) {
+	return c.setMMSFeature(ctx, http.MethodPut, siteID, peerID, feature)
+}
+
+func (c *Client) setMMSFeature(ctx context.Context, method, siteID, peerID string, feature *MmsFeature) (*MmsFeatureResponse, error) {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/mms"
+	result, _, err := c.makeAccountsRequest(ctx, method, path, &MmsFeatureResponse{}, feature)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*MmsFeatureResponse), nil
+}
 
-	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &MmsFeatureResponse{}, &feature)
+// GetMMSFeature returns the MMS settings of the sip-peer.
+func (c *Client) GetMMSFeature(ctx context.Context, siteID, peerID string) (*MmsFeatureResponse, error) {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/mms"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &MmsFeatureResponse{})
 	if err != nil {
 		return nil, err
 	}
 	return result.(*MmsFeatureResponse), nil
 }
 
+// DisableMMS disables MMS on the sip-peer.
+func (c *Client) DisableMMS(ctx context.Context, siteID, peerID string) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/features/mms"
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
 // AssociateApplication associates the peer with the application.
 func (c *Client) AssociateApplication(ctx context.Context, siteID, peerID, applicationID string) (*ApplicationsSettingsResponse, error) {
 	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/applicationSettings"
@@ -76,6 +235,66 @@ func (c *Client) AssociateApplication(ctx context.Context, siteID, peerID, appli
 	return result.(*ApplicationsSettingsResponse), nil
 }
 
+// DisassociateApplication removes the application from the peer.
+func (c *Client) DisassociateApplication(ctx context.Context, siteID, peerID string) error {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/products/messaging/applicationSettings"
+	req := removeApplicationsSettings{Value: "REMOVE"}
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodPut, path, nil, &req)
+	return err
+}
+
+// CreateMessagingApplication creates the messaging (v2) application.
+func (c *Client) CreateMessagingApplication(ctx context.Context, application *Application) (*Application, error) {
+	path := c.AccountsEndpoint + "/applications"
+	req := *application
+	req.ApplicationID = ""
+	req.ServiceType = MessagingServiceType
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &ApplicationProvisioningResponse{}, &req)
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*ApplicationProvisioningResponse).Application, nil
+}
+
+// GetApplication returns the application.
+func (c *Client) GetApplication(ctx context.Context, applicationID string) (*Application, error) {
+	path := c.AccountsEndpoint + "/applications/" + applicationID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &ApplicationProvisioningResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*ApplicationProvisioningResponse).Application, nil
+}
+
+// ListApplications returns all applications of the account.
+func (c *Client) ListApplications(ctx context.Context) ([]Application, error) {
+	path := c.AccountsEndpoint + "/applications"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &ApplicationProvisioningResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ApplicationProvisioningResponse).ApplicationList, nil
+}
+
+// UpdateApplication replaces the application settings.
+func (c *Client) UpdateApplication(ctx context.Context, applicationID string, application *Application) (*Application, error) {
+	path := c.AccountsEndpoint + "/applications/" + applicationID
+	req := *application
+	req.ApplicationID = ""
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPut, path, &ApplicationProvisioningResponse{}, &req)
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*ApplicationProvisioningResponse).Application, nil
+}
+
+// DeleteApplication deletes the application. It must not be associated with any peer.
+func (c *Client) DeleteApplication(ctx context.Context, applicationID string) error {
+	path := c.AccountsEndpoint + "/applications/" + applicationID
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
 // GetAssociatedPeers returns the associated sippeers (aka locations) for the application.
 func (c *Client) GetAssociatedPeers(ctx context.Context, applicationID string) (*AssociatedSipPeersResponse, error) {
 	path := c.AccountsEndpoint + "/applications/" + applicationID + "/associatedsippeers"
@@ -96,20 +315,183 @@ func (c *Client) GetNumbers(ctx context.Context, siteID, peerID string) (*SipPee
 	return result.(*SipPeerTelephoneNumbersResponse), nil
 }
 
+// ListPeerNumbers returns the iterator over all numbers of the sip-peer.
+func (c *Client) ListPeerNumbers(siteID, peerID string) *NumberIterator {
+	path := c.AccountsEndpoint + "/sites/" + siteID + "/sippeers/" + peerID + "/tns"
+	pages := c.newPageIterator(path, nil, func() pagedResponse { return &SipPeerTelephoneNumbersResponse{} })
+	return &NumberIterator{pages: pages, numbers: func(page interface{}) []string {
+		var numbers []string
+		for _, number := range page.(*SipPeerTelephoneNumbersResponse).Peers.Numbers {
+			numbers = append(numbers, number.FullNumber)
+		}
+		return numbers
+	}}
+}
+
+// ErrNumberNotOnPeer is the failure of a number passed to MoveNumbers that isn't on fromPeer.
+var ErrNumberNotOnPeer = errors.New("number is not on the sip-peer")
+
+// moveNumbersBatchSize is the maximum count of numbers moved by a single request.
+var moveNumbersBatchSize = 5000
+
+// MoveNumbers moves the numbers of the sip-peer fromPeer to the sip-peer toPeer of the site.
+// All numbers of fromPeer are moved if tns is empty, otherwise the numbers of tns which aren't on fromPeer
+// fail with ErrNumberNotOnPeer. The numbers are sent in batches of the size allowed by the API,
+// failure of a batch doesn't stop moving of the next ones.
+// The returned error is set only if the move couldn't continue (like a cancelled context),
+// the result has the numbers processed until then even with the error.
+func (c *Client) MoveNumbers(ctx context.Context, fromSite, fromPeer, toPeer string, tns []string) (*MoveNumbersResult, error) {
+	result := &MoveNumbersResult{}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	var peerNumbers []string
+	numbers := c.ListPeerNumbers(fromSite, fromPeer)
+	for numbers.Next(ctx) {
+		peerNumbers = append(peerNumbers, numbers.Number())
+	}
+	if err := numbers.Err(); err != nil {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		return result, err
+	}
+	if len(tns) == 0 {
+		tns = peerNumbers
+	} else {
+		onPeer := make(map[string]bool, len(peerNumbers))
+		for _, number := range peerNumbers {
+			onPeer[number] = true
+		}
+		var members []string
+		for _, number := range tns {
+			if onPeer[number] {
+				members = append(members, number)
+			} else {
+				result.Failed = append(result.Failed, NumberError{TelephoneNumber: number, Err: ErrNumberNotOnPeer})
+			}
+		}
+		tns = members
+	}
+	path := c.AccountsEndpoint + "/sites/" + fromSite + "/sippeers/" + toPeer + "/movetns"
+	for start := 0; start < len(tns); start += moveNumbersBatchSize {
+		end := start + moveNumbersBatchSize
+		if end > len(tns) {
+			end = len(tns)
+		}
+		batch := tns[start:end]
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		response, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &moveNumbersResponse{}, &moveNumbersRequest{FullNumber: batch})
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			var details []ErrorDetail
+			var apiError *APIError
+			if errors.As(err, &apiError) {
+				details = apiError.Details
+			}
+			result.addMoved(batch, details, err)
+			continue
+		}
+		result.addMoved(batch, response.(*moveNumbersResponse).ErrorList, nil)
+	}
+	return result, nil
+}
+
+// addMoved records the result of the batch. Numbers with an error detail failed, the rest of the batch
+// failed with batchErr (if set) or has been moved.
+func (r *MoveNumbersResult) addMoved(batch []string, details []ErrorDetail, batchErr error) {
+	failed := make(map[string]error)
+	for _, detail := range details {
+		if detail.TelephoneNumber != "" {
+			failed[detail.TelephoneNumber] = &APIError{Code: detail.Code, Description: detail.Description}
+		}
+	}
+	for _, number := range batch {
+		err, ok := failed[number]
+		if !ok {
+			err = batchErr
+		}
+		if err != nil {
+			r.Failed = append(r.Failed, NumberError{TelephoneNumber: number, Err: err})
+		} else {
+			r.Moved.TelephoneNumber = append(r.Moved.TelephoneNumber, number)
+		}
+	}
+}
+
+// InServiceNumberPages returns the iterator over the pages (*InServiceNumbersResponse) of in-service numbers of the account.
+func (c *Client) InServiceNumberPages(filter *InServiceNumbersFilter) *PageIterator {
+	path := c.AccountsEndpoint + "/inserviceNumbers"
+	if filter == nil {
+		filter = &InServiceNumbersFilter{}
+	}
+	params := map[string]string{"page": "1", "size": "500"}
+	if filter.PageSize > 0 {
+		params["size"] = strconv.Itoa(filter.PageSize)
+	}
+	for key, value := range map[string]string{
+		"areacode":   filter.AreaCode,
+		"npanxx":     filter.NpaNxx,
+		"state":      filter.State,
+		"lata":       filter.Lata,
+		"ratecenter": filter.RateCenter,
+	} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	return c.newPageIterator(path, params, func() pagedResponse { return &InServiceNumbersResponse{} })
+}
+
+// ListInServiceNumbers returns the iterator over the in-service numbers of the account.
+// The pages are fetched lazily.
+func (c *Client) ListInServiceNumbers(filter *InServiceNumbersFilter) *NumberIterator {
+	return &NumberIterator{pages: c.InServiceNumberPages(filter), numbers: func(page interface{}) []string {
+		return page.(*InServiceNumbersResponse).TelephoneNumbers
+	}}
+}
+
 // OrderNumbersByAreaCode purchases n numbers given area-code.
 func (c *Client) OrderNumbersByAreaCode(ctx context.Context, siteID, peerID, areaCode string, n int) (*OrderResponse, error) {
-	path := c.AccountsEndpoint + "/orders"
-	req := AreaCodeRequest{
-		AreaCodeOrder: AreaCodeOrder{
-			SiteID: siteID,
-			PeerID: peerID,
-			AreaCodeSearchAndOrderType: AreaCodeSearchAndOrderType{
-				Quantity: n,
-				AreaCode: areaCode,
-			},
+	return c.OrderNumbers(ctx, &NumberOrder{
+		SiteID: siteID,
+		PeerID: peerID,
+		AreaCodeSearchAndOrderType: &AreaCodeSearchAndOrderType{
+			Quantity: n,
+			AreaCode: areaCode,
 		},
+	})
+}
+
+// OrderNumbers submits the order of numbers. Exactly one order type must be set.
+func (c *Client) OrderNumbers(ctx context.Context, order *NumberOrder) (*OrderResponse, error) {
+	types := 0
+	for _, orderType := range []bool{
+		order.ExistingTelephoneNumberOrderType != nil,
+		order.AreaCodeSearchAndOrderType != nil,
+		order.NPANXXSearchAndOrderType != nil,
+		order.RateCenterSearchAndOrderType != nil,
+		order.ZIPSearchAndOrderType != nil,
+		order.CitySearchAndOrderType != nil,
+		order.StateSearchAndOrderType != nil,
+		order.LATASearchAndOrderType != nil,
+		order.CombinedSearchAndOrderType != nil,
+		order.TollFreeVanitySearchAndOrderType != nil,
+		order.TollFreeWildCharSearchAndOrderType != nil,
+	} {
+		if orderType {
+			types++
+		}
 	}
-	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &OrderResponse{}, &req)
+	if types != 1 {
+		return nil, fmt.Errorf("exactly one order type is required, got %d", types)
+	}
+	path := c.AccountsEndpoint + "/orders"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &OrderResponse{}, order)
 	if err != nil {
 		return nil, err
 	}
@@ -132,22 +514,45 @@ func (c *Client) SearchNumbersByAreaCode(ctx context.Context, areaCode string, n
 
 // OrderTollFreeNumbers purchases n numbers given toll-free mask.
 func (c *Client) OrderTollFreeNumbers(ctx context.Context, siteID, peerID, mask string, n int) (*OrderResponse, error) {
-	path := c.AccountsEndpoint + "/orders"
-	req := TollFreeOrderRequest{
-		TollFreeOrder: TollFreeOrder{
-			SiteID: siteID,
-			PeerID: peerID,
-			TollFreeWildCharSearchAndOrderType: TollFreeWildCharSearchAndOrderType{
-				Quantity:                n,
-				TollFreeWildCardPattern: mask,
-			},
+	return c.OrderNumbers(ctx, &NumberOrder{
+		SiteID: siteID,
+		PeerID: peerID,
+		TollFreeWildCharSearchAndOrderType: &TollFreeWildCharSearchAndOrderType{
+			Quantity:                n,
+			TollFreeWildCardPattern: mask,
 		},
+	})
+}
+
+// ReserveNumber reserves the number (found by a search) until it is ordered and returns the reservation ID.
+func (c *Client) ReserveNumber(ctx context.Context, number string) (string, error) {
+	path := c.AccountsEndpoint + "/tnreservation"
+	_, headers, err := c.makeAccountsRequest(ctx, http.MethodPost, path, nil, &Reservation{ReservedTn: number})
+	if err != nil {
+		return "", err
+	}
+	id := idFromLocation(headers, "/tnreservation/")
+	if id == "" {
+		return "", fmt.Errorf("unknown reservation ID: %v", headers.Get("Location"))
 	}
-	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &OrderResponse{}, &req)
+	return id, nil
+}
+
+// GetReservation returns the reservation.
+func (c *Client) GetReservation(ctx context.Context, reservationID string) (*Reservation, error) {
+	path := c.AccountsEndpoint + "/tnreservation/" + reservationID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &ReservationResponse{})
 	if err != nil {
 		return nil, err
 	}
-	return result.(*OrderResponse), nil
+	return &result.(*ReservationResponse).Reservation, nil
+}
+
+// DeleteReservation releases the reserved number.
+func (c *Client) DeleteReservation(ctx context.Context, reservationID string) error {
+	path := c.AccountsEndpoint + "/tnreservation/" + reservationID
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
 }
 
 // SearchTollFreeNumbers finds n numbers given tollfree mask.
@@ -200,3 +605,140 @@ func (c *Client) GetOrder(ctx context.Context, id string) (*OrderResponse, error
 	}
 	return result.(*OrderResponse), nil
 }
+
+// OrderPages returns the iterator over the pages (*OrdersResponse) of the orders of the account.
+func (c *Client) OrderPages(filter *OrdersFilter) *PageIterator {
+	return c.orderPages(c.AccountsEndpoint+"/orders", filter, func() pagedResponse { return &OrdersResponse{} })
+}
+
+// DisconnectPages returns the iterator over the pages (*OrdersResponse) of the disconnect orders of the account.
+func (c *Client) DisconnectPages(filter *OrdersFilter) *PageIterator {
+	return c.orderPages(c.AccountsEndpoint+"/disconnects", filter, func() pagedResponse { return &OrdersResponse{} })
+}
+
+func (c *Client) orderPages(path string, filter *OrdersFilter, newPage func() pagedResponse) *PageIterator {
+	if filter == nil {
+		filter = &OrdersFilter{}
+	}
+	params := map[string]string{"page": "1", "size": "300"}
+	if filter.PageSize > 0 {
+		params["size"] = strconv.Itoa(filter.PageSize)
+	}
+	if !filter.StartDate.IsZero() {
+		params["startdate"] = filter.StartDate.Format("2006-01-02")
+	}
+	if !filter.EndDate.IsZero() {
+		params["enddate"] = filter.EndDate.Format("2006-01-02")
+	}
+	for key, value := range map[string]string{
+		"status":          filter.Status,
+		"customerOrderId": filter.CustomerOrderID,
+		"tn":              filter.TelephoneNumber,
+	} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	return c.newPageIterator(path, params, newPage)
+}
+
+// ListOrders returns the iterator over the orders of the account.
+// The pages are fetched when needed.
+func (c *Client) ListOrders(filter *OrdersFilter) *OrderIterator {
+	return &OrderIterator{pages: c.OrderPages(filter)}
+}
+
+// ListDisconnects returns the iterator over the disconnect orders of the account.
+// The pages are fetched when needed.
+func (c *Client) ListDisconnects(filter *OrdersFilter) *OrderIterator {
+	return &OrderIterator{pages: c.DisconnectPages(filter)}
+}
+
+// SubmitTnOptionOrder submits the order changing the settings of the individual numbers.
+func (c *Client) SubmitTnOptionOrder(ctx context.Context, order *TnOptionOrder) (*TnOptionOrderDetails, error) {
+	path := c.AccountsEndpoint + "/tnoptions"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &TnOptionOrderResponse{}, order)
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*TnOptionOrderResponse).TnOptionOrder, nil
+}
+
+// GetTnOptionOrder returns the state of the TN option order.
+func (c *Client) GetTnOptionOrder(ctx context.Context, orderID string) (*TnOptionOrderDetails, error) {
+	path := c.AccountsEndpoint + "/tnoptions/" + orderID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &TnOptionOrderDetails{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*TnOptionOrderDetails), nil
+}
+
+// TnOptionOrderPages returns the iterator over the pages (*TnOptionOrdersResponse) of the TN option orders.
+func (c *Client) TnOptionOrderPages(filter *OrdersFilter) *PageIterator {
+	return c.orderPages(c.AccountsEndpoint+"/tnoptions", filter, func() pagedResponse { return &TnOptionOrdersResponse{} })
+}
+
+// GetNumberDetails returns where the number lives (site, sip-peer) and its details.
+func (c *Client) GetNumberDetails(ctx context.Context, number string) (*TelephoneNumberDetails, error) {
+	path := c.tnsEndpoint + number + "/tndetails"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &TelephoneNumberDetailsResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*TelephoneNumberDetailsResponse).TelephoneNumberDetails, nil
+}
+
+// GetNumberHistory returns the orders and disconnects of the number, oldest first.
+func (c *Client) GetNumberHistory(ctx context.Context, number string) ([]OrderSummary, error) {
+	filter := &OrdersFilter{TelephoneNumber: number}
+	var history []OrderSummary
+	for _, orders := range []*OrderIterator{c.ListOrders(filter), c.ListDisconnects(filter)} {
+		for orders.Next(ctx) {
+			history = append(history, orders.Order())
+		}
+		if err := orders.Err(); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].OrderDate.Before(history[j].OrderDate)
+	})
+	return history, nil
+}
+
+// GetOrderHistory returns the status changes of the order.
+func (c *Client) GetOrderHistory(ctx context.Context, orderID string) ([]OrderHistoryItem, error) {
+	path := c.AccountsEndpoint + "/orders/" + orderID + "/history"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &OrderHistoryResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*OrderHistoryResponse).History, nil
+}
+
+// GetOrderNotes returns the notes of the order.
+func (c *Client) GetOrderNotes(ctx context.Context, orderID string) ([]OrderNote, error) {
+	path := c.AccountsEndpoint + "/orders/" + orderID + "/notes"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &OrderNotesResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*OrderNotesResponse).Notes, nil
+}
+
+// AddOrderNote adds the note to the order and returns the note ID.
+// It isn't retried unless RetryPolicy.RetryNonIdempotent is set, as a repeated PUT adds the note again.
+func (c *Client) AddOrderNote(ctx context.Context, orderID, userID, description string) (string, error) {
+	path := c.AccountsEndpoint + "/orders/" + orderID + "/notes"
+	note := &OrderNote{UserID: userID, Description: description}
+	_, headers, err := c.makeAccountsRequest(withNonIdempotent(ctx), http.MethodPut, path, nil, note)
+	if err != nil {
+		return "", err
+	}
+	id := idFromLocation(headers, "/notes/")
+	if id == "" {
+		return "", fmt.Errorf("unknown note ID: %v", headers.Get("Location"))
+	}
+	return id, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sort"
 	"testing"
+	"time"
 )
 
 func TestEnableMMS(t *testing.T) {
@@ -47,7 +49,7 @@ func TestEnableSMS(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/sms", accountsPath, testAccountID, siteID, peerID),
 		Method:           http.MethodPost,
-		EstimatedContent: fmt.Sprintf(`<SipPeerSmsFeature><SipPeerSmsFeatureSettings><TollFree>true</TollFree><ShortCode>true</ShortCode><A2pLongCode>DefaultOff</A2pLongCode><Protocol>HTTP</Protocol><Zone1>true</Zone1><Zone2>false</Zone2><Zone3>false</Zone3><Zone4>false</Zone4><Zone5>false</Zone5></SipPeerSmsFeatureSettings><HttpSettings></HttpSettings></SipPeerSmsFeature>`),
+		EstimatedContent: fmt.Sprintf(`<SipPeerSmsFeature><SipPeerSmsFeatureSettings><TollFree>true</TollFree><ShortCode>false</ShortCode><A2pLongCode>DefaultOff</A2pLongCode><Protocol>HTTP</Protocol><Zone1>true</Zone1><Zone2>false</Zone2><Zone3>false</Zone3><Zone4>false</Zone4><Zone5>false</Zone5></SipPeerSmsFeatureSettings><HttpSettings></HttpSettings></SipPeerSmsFeature>`),
 		ContentToSend: fmt.Sprintf(`
 		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
 		<SipPeerSmsFeatureResponse>
@@ -272,6 +274,153 @@ func TestOrderTollFreeNumbers(t *testing.T) {
 	expect(t, result.OrderStatus, "RECEIVED")
 }
 
+func TestOrderNumbers(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/orders", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<Order><Name>order</Name><SiteId>12345</SiteId><PartialAllowed>true</PartialAllowed><BackOrderRequested>true</BackOrderRequested><CustomerOrderId>abc</CustomerOrderId><ExistingTelephoneNumberOrderType><TelephoneNumberList><TelephoneNumber>9195551212</TelephoneNumber><TelephoneNumber>9195551213</TelephoneNumber></TelephoneNumberList></ExistingTelephoneNumberOrderType></Order>",
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<OrderResponse>
+			<Order>
+				<Name>order</Name>
+				<CustomerOrderId>abc</CustomerOrderId>
+				<OrderCreateDate>2019-11-05T13:48:43.238Z</OrderCreateDate>
+				<BackOrderRequested>true</BackOrderRequested>
+				<id>1-2-3-4</id>
+				<PartialAllowed>true</PartialAllowed>
+				<SiteId>12345</SiteId>
+			</Order>
+			<OrderStatus>RECEIVED</OrderStatus>
+		</OrderResponse>`}})
+	defer server.Close()
+	result, err := api.OrderNumbers(context.Background(), &NumberOrder{
+		Name:               "order",
+		SiteID:             "12345",
+		PartialAllowed:     true,
+		BackOrderRequested: true,
+		CustomerOrderID:    "abc",
+		ExistingTelephoneNumberOrderType: &ExistingTelephoneNumberOrderType{
+			TelephoneNumberList: TelephoneNumberList{TelephoneNumber: []string{"9195551212", "9195551213"}},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed call of OrderNumbers(): %v", err)
+		return
+	}
+	expect(t, result.Order.ID, "1-2-3-4")
+	expect(t, result.Order.CustomerOrderID, "abc")
+}
+
+func TestOrderNumbersByRateCenter(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/orders", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<Order><SiteId>12345</SiteId><PartialAllowed>false</PartialAllowed><RateCenterSearchAndOrderType><RateCenter>RALEIGH</RateCenter><State>NC</State><Quantity>2</Quantity></RateCenterSearchAndOrderType></Order>",
+		ContentToSend:    `<OrderResponse><Order><id>1-2-3-4</id></Order><OrderStatus>RECEIVED</OrderStatus></OrderResponse>`}})
+	defer server.Close()
+	result, err := api.OrderNumbers(context.Background(), &NumberOrder{
+		SiteID:                       "12345",
+		RateCenterSearchAndOrderType: &RateCenterSearchAndOrderType{RateCenter: "RALEIGH", State: "NC", Quantity: 2},
+	})
+	if err != nil {
+		t.Errorf("Failed call of OrderNumbers(): %v", err)
+		return
+	}
+	expect(t, result.OrderStatus, "RECEIVED")
+}
+
+func TestOrderReservedNumbers(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/orders", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<Order><SiteId>12345</SiteId><PartialAllowed>false</PartialAllowed><ExistingTelephoneNumberOrderType><TelephoneNumberList><TelephoneNumber>9195551212</TelephoneNumber></TelephoneNumberList><ReservationIdList><ReservationId>res-1</ReservationId></ReservationIdList></ExistingTelephoneNumberOrderType></Order>",
+		ContentToSend:    `<OrderResponse><Order><id>1-2-3-4</id></Order><OrderStatus>RECEIVED</OrderStatus></OrderResponse>`}})
+	defer server.Close()
+	result, err := api.OrderNumbers(context.Background(), &NumberOrder{
+		SiteID: "12345",
+		ExistingTelephoneNumberOrderType: &ExistingTelephoneNumberOrderType{
+			TelephoneNumberList: TelephoneNumberList{TelephoneNumber: []string{"9195551212"}},
+			ReservationIDList:   &ReservationIDList{ReservationID: []string{"res-1"}},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed call of OrderNumbers(): %v", err)
+		return
+	}
+	expect(t, result.Order.ID, "1-2-3-4")
+}
+
+func TestReserveNumber(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/tnreservation", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<Reservation><ReservedTn>9195551212</ReservedTn></Reservation>",
+		HeadersToSend: map[string]string{
+			"Location": fmt.Sprintf("https://dashboard.bandwidth.com:443/v1.0/accounts/%s/tnreservation/res-1", testAccountID),
+		},
+		StatusCodeToSend: http.StatusCreated,
+	}})
+	defer server.Close()
+	id, err := api.ReserveNumber(context.Background(), "9195551212")
+	if err != nil {
+		t.Errorf("Failed call of ReserveNumber(): %v", err)
+		return
+	}
+	expect(t, id, "res-1")
+}
+
+func TestGetReservation(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/tnreservation/res-1", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: fmt.Sprintf(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<ReservationResponse>
+			<Reservation>
+				<ReservationId>res-1</ReservationId>
+				<AccountId>%s</AccountId>
+				<ReservationExpires>1439</ReservationExpires>
+				<ReservedTn>9195551212</ReservedTn>
+			</Reservation>
+		</ReservationResponse>`, testAccountID),
+	}})
+	defer server.Close()
+	result, err := api.GetReservation(context.Background(), "res-1")
+	if err != nil {
+		t.Errorf("Failed call of GetReservation(): %v", err)
+		return
+	}
+	expect(t, result.ReservationID, "res-1")
+	expect(t, result.ReservationExpires, 1439)
+	expect(t, result.ReservedTn, "9195551212")
+}
+
+func TestDeleteReservation(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/tnreservation/res-1", accountsPath, testAccountID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	if err := api.DeleteReservation(context.Background(), "res-1"); err != nil {
+		t.Errorf("Failed call of DeleteReservation(): %v", err)
+	}
+}
+
+func TestOrderNumbersWithoutSingleOrderType(t *testing.T) {
+	api := getAPI("http://localhost")
+	shouldFail(t, func() (interface{}, error) {
+		return api.OrderNumbers(context.Background(), &NumberOrder{SiteID: "12345"})
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return api.OrderNumbers(context.Background(), &NumberOrder{
+			SiteID:                  "12345",
+			ZIPSearchAndOrderType:   &ZIPSearchAndOrderType{Zip: "27606", Quantity: 1},
+			StateSearchAndOrderType: &StateSearchAndOrderType{State: "NC", Quantity: 1},
+		})
+	})
+}
+
 func TestGetTollFreeOrder(t *testing.T) {
 	siteID := "12345"
 	peerID := "123123"
@@ -369,6 +518,475 @@ func TestGetOrderByAreaCode(t *testing.T) {
 	expect(t, result.CompletedNumbers.TelephoneNumbers[0].FullNumber, number)
 }
 
+func TestListOrders(t *testing.T) {
+	path := fmt.Sprintf("%s%s/orders", accountsPath, testAccountID)
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery: path + "?customerOrderId=abc&enddate=2019-11-30&page=1&size=1&startdate=2019-11-01&status=COMPLETE&tn=9195551212",
+			ContentToSend: `
+			<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+			<ResponseSelectWrapper>
+				<ListOrderIdUserIdDate>
+					<TotalCount>2</TotalCount>
+					<Links>
+						<next>Link=&lt;https://dashboard.bandwidth.com:443/v1.0/accounts/123/orders?customerOrderId=abc&amp;enddate=2019-11-30&amp;page=2&amp;size=1&amp;startdate=2019-11-01&amp;status=COMPLETE&amp;tn=9195551212&gt;;rel="next";</next>
+					</Links>
+					<OrderIdUserIdDate>
+						<accountId>123</accountId>
+						<CountOfTNs>1</CountOfTNs>
+						<CustomerOrderId>abc</CustomerOrderId>
+						<userId>team</userId>
+						<lastModifiedDate>2019-11-05T13:48:45.123Z</lastModifiedDate>
+						<OrderDate>2019-11-05T13:48:43.238Z</OrderDate>
+						<OrderType>new_number</OrderType>
+						<orderId>1-2-3-4</orderId>
+						<OrderStatus>COMPLETE</OrderStatus>
+					</OrderIdUserIdDate>
+				</ListOrderIdUserIdDate>
+			</ResponseSelectWrapper>`,
+		},
+		RequestHandler{
+			PathAndQuery: path + "?customerOrderId=abc&enddate=2019-11-30&page=2&size=1&startdate=2019-11-01&status=COMPLETE&tn=9195551212",
+			ContentToSend: `
+			<ResponseSelectWrapper>
+				<ListOrderIdUserIdDate>
+					<TotalCount>2</TotalCount>
+					<OrderIdUserIdDate>
+						<CountOfTNs>3</CountOfTNs>
+						<orderId>5-6-7-8</orderId>
+						<OrderStatus>COMPLETE</OrderStatus>
+					</OrderIdUserIdDate>
+				</ListOrderIdUserIdDate>
+			</ResponseSelectWrapper>`,
+		},
+	})
+	defer server.Close()
+	it := api.ListOrders(&OrdersFilter{
+		Status:          OrderStatusComplete,
+		StartDate:       time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2019, 11, 30, 0, 0, 0, 0, time.UTC),
+		CustomerOrderID: "abc",
+		TelephoneNumber: "9195551212",
+		PageSize:        1,
+	})
+	var orders []OrderSummary
+	for it.Next(context.Background()) {
+		orders = append(orders, it.Order())
+	}
+	expectNil(t, it.Err())
+	expect(t, len(orders), 2)
+	expect(t, orders[0].OrderID, "1-2-3-4")
+	expect(t, orders[0].OrderType, "new_number")
+	expect(t, orders[0].CountOfTNs, 1)
+	expect(t, orders[1].OrderID, "5-6-7-8")
+}
+
+func TestMoveNumbers(t *testing.T) {
+	batchSize := moveNumbersBatchSize
+	moveNumbersBatchSize = 2
+	defer func() { moveNumbersBatchSize = batchSize }()
+	path := fmt.Sprintf("%s%s/sites/12345/sippeers/456/movetns", accountsPath, testAccountID)
+	expectedBodies := []string{
+		"<SipPeerTelephoneNumbers><FullNumber>9195551211</FullNumber><FullNumber>9195551212</FullNumber></SipPeerTelephoneNumbers>",
+		"<SipPeerTelephoneNumbers><FullNumber>9195551213</FullNumber><FullNumber>9195551214</FullNumber></SipPeerTelephoneNumbers>",
+		"<SipPeerTelephoneNumbers><FullNumber>9195551215</FullNumber></SipPeerTelephoneNumbers>",
+	}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			expect(t, r.URL.String(), fmt.Sprintf("%s%s/sites/12345/sippeers/123/tns", accountsPath, testAccountID))
+			fmt.Fprint(w, `<SipPeerTelephoneNumbersResponse><SipPeerTelephoneNumbers>
+				<SipPeerTelephoneNumber><FullNumber>9195551211</FullNumber></SipPeerTelephoneNumber>
+				<SipPeerTelephoneNumber><FullNumber>9195551212</FullNumber></SipPeerTelephoneNumber>
+				<SipPeerTelephoneNumber><FullNumber>9195551213</FullNumber></SipPeerTelephoneNumber>
+				<SipPeerTelephoneNumber><FullNumber>9195551214</FullNumber></SipPeerTelephoneNumber>
+				<SipPeerTelephoneNumber><FullNumber>9195551215</FullNumber></SipPeerTelephoneNumber>
+			</SipPeerTelephoneNumbers></SipPeerTelephoneNumbersResponse>`)
+			return
+		}
+		expect(t, r.Method, http.MethodPost)
+		expect(t, r.URL.String(), path)
+		expect(t, readText(t, r.Body), expectedBodies[calls])
+		calls++
+		switch calls {
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<MoveTnsResponse><ErrorList><Error><Code>5095</Code><Description>Peer is not in service</Description></Error></ErrorList></MoveTnsResponse>`)
+		case 3:
+			fmt.Fprint(w, `<MoveTnsResponse><ErrorList><Error><Code>5082</Code><Description>Number is not on the account</Description><TelephoneNumber>9195551215</TelephoneNumber></Error></ErrorList></MoveTnsResponse>`)
+		}
+	}))
+	defer server.Close()
+	api := getAPI(server.URL)
+	result, err := api.MoveNumbers(context.Background(), "12345", "123", "456",
+		[]string{"9195551211", "9195551210", "9195551212", "9195551213", "9195551214", "9195551215"})
+	if err != nil {
+		t.Errorf("Failed call of MoveNumbers(): %v", err)
+		return
+	}
+	expect(t, calls, 3)
+	expect(t, result.Moved.TelephoneNumber, []string{"9195551211", "9195551212"})
+	expect(t, len(result.Failed), 4)
+	expect(t, result.Failed[0].TelephoneNumber, "9195551210")
+	expect(t, result.Failed[0].Err, ErrNumberNotOnPeer)
+	expect(t, result.Failed[1].TelephoneNumber, "9195551213")
+	expect(t, result.Failed[1].Err.Error(), "Peer is not in service")
+	expect(t, result.Failed[3].TelephoneNumber, "9195551215")
+	expect(t, result.Failed[3].Err.(*APIError).Code, "5082")
+}
+
+func TestMoveAllNumbers(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/sites/12345/sippeers/123/tns", accountsPath, testAccountID),
+			ContentToSend: `
+			<SipPeerTelephoneNumbersResponse>
+				<SipPeerTelephoneNumbers>
+					<SipPeerTelephoneNumber><FullNumber>9195551211</FullNumber></SipPeerTelephoneNumber>
+					<SipPeerTelephoneNumber><FullNumber>9195551212</FullNumber></SipPeerTelephoneNumber>
+				</SipPeerTelephoneNumbers>
+			</SipPeerTelephoneNumbersResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery:     fmt.Sprintf("%s%s/sites/12345/sippeers/456/movetns", accountsPath, testAccountID),
+			Method:           http.MethodPost,
+			EstimatedContent: "<SipPeerTelephoneNumbers><FullNumber>9195551211</FullNumber><FullNumber>9195551212</FullNumber></SipPeerTelephoneNumbers>",
+		},
+	})
+	defer server.Close()
+	result, err := api.MoveNumbers(context.Background(), "12345", "123", "456", nil)
+	if err != nil {
+		t.Errorf("Failed call of MoveNumbers(): %v", err)
+		return
+	}
+	expect(t, result.Moved.TelephoneNumber, []string{"9195551211", "9195551212"})
+	expect(t, len(result.Failed), 0)
+}
+
+func TestMoveNumbersListFailed(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/12345/sippeers/123/tns", accountsPath, testAccountID),
+		StatusCodeToSend: http.StatusInternalServerError,
+	}})
+	defer server.Close()
+	result, err := api.MoveNumbers(context.Background(), "12345", "123", "456", []string{"9195551211"})
+	if err == nil {
+		t.Fatal("Should fail here")
+	}
+	expect(t, len(result.Failed), 0)
+	expect(t, len(result.Moved.TelephoneNumber), 0)
+}
+
+func TestMoveNumbersContextCancelled(t *testing.T) {
+	api := getAPI("http://localhost")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := api.MoveNumbers(ctx, "12345", "123", "456", []string{"9195551211"})
+	expect(t, err, context.Canceled)
+	expect(t, len(result.Moved.TelephoneNumber), 0)
+}
+
+func TestSubmitTnOptionOrder(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/tnoptions", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<TnOptionOrder><CustomerOrderId>abc</CustomerOrderId><TnOptionGroups><TnOptionGroup><Sms>on</Sms><A2pSettings><Action>asSpecified</Action><MessageClass>Campaign-A</MessageClass><CampaignId>CJEUMDK</CampaignId></A2pSettings><TelephoneNumbers><TelephoneNumber>9195551212</TelephoneNumber></TelephoneNumbers></TnOptionGroup><TnOptionGroup><PortOutPasscode>a1b2c3</PortOutPasscode><CallingNameDisplay>off</CallingNameDisplay><TelephoneNumbers><TelephoneNumber>9195551213</TelephoneNumber><TelephoneNumber>9195551214</TelephoneNumber></TelephoneNumbers></TnOptionGroup></TnOptionGroups></TnOptionOrder>",
+		StatusCodeToSend: http.StatusCreated,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<TnOptionOrderResponse>
+			<TnOptionOrder>
+				<OrderCreateDate>2019-11-05T13:48:43.238Z</OrderCreateDate>
+				<AccountId>123</AccountId>
+				<CreatedByUser>team</CreatedByUser>
+				<OrderId>1-2-3-4</OrderId>
+				<LastModifiedDate>2019-11-05T13:48:43.238Z</LastModifiedDate>
+				<ProcessingStatus>RECEIVED</ProcessingStatus>
+				<CustomerOrderId>abc</CustomerOrderId>
+				<TnOptionGroups>
+					<TnOptionGroup>
+						<Sms>on</Sms>
+						<TelephoneNumbers>
+							<TelephoneNumber>9195551212</TelephoneNumber>
+						</TelephoneNumbers>
+					</TnOptionGroup>
+				</TnOptionGroups>
+				<ErrorList/>
+			</TnOptionOrder>
+		</TnOptionOrderResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.SubmitTnOptionOrder(context.Background(), &TnOptionOrder{
+		CustomerOrderID: "abc",
+		TnOptionGroups: []TnOptionGroup{
+			TnOptionGroup{
+				Sms:              TnOptionOn,
+				A2pSettings:      &A2pSettings{Action: "asSpecified", MessageClass: "Campaign-A", CampaignID: "CJEUMDK"},
+				TelephoneNumbers: TelephoneNumberList{TelephoneNumber: []string{"9195551212"}},
+			},
+			TnOptionGroup{
+				PortOutPasscode:    "a1b2c3",
+				CallingNameDisplay: TnOptionOff,
+				TelephoneNumbers:   TelephoneNumberList{TelephoneNumber: []string{"9195551213", "9195551214"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Failed call of SubmitTnOptionOrder(): %v", err)
+		return
+	}
+	expect(t, result.OrderID, "1-2-3-4")
+	expect(t, result.ProcessingStatus, OrderStatusReceived)
+	expect(t, result.TnOptionGroups[0].TelephoneNumbers.TelephoneNumber, []string{"9195551212"})
+}
+
+func TestGetTnOptionOrder(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/tnoptions/1-2-3-4", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<TnOptionOrder>
+			<OrderId>1-2-3-4</OrderId>
+			<ProcessingStatus>PARTIAL</ProcessingStatus>
+			<ErrorList>
+				<Error>
+					<Code>5076</Code>
+					<Description>Telephone number is not available</Description>
+					<TelephoneNumber>9195551213</TelephoneNumber>
+				</Error>
+			</ErrorList>
+		</TnOptionOrder>`,
+	}})
+	defer server.Close()
+	result, err := api.GetTnOptionOrder(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of GetTnOptionOrder(): %v", err)
+		return
+	}
+	expect(t, result.ProcessingStatus, OrderStatusPartial)
+	expect(t, result.ErrorList[0].TelephoneNumber, "9195551213")
+}
+
+func TestTnOptionOrderPages(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/tnoptions?page=1&size=300&status=FAILED&tn=9195551212", accountsPath, testAccountID),
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<TnOptionOrders>
+			<TotalCount>1</TotalCount>
+			<TnOptionOrderSummary>
+				<accountId>123</accountId>
+				<CountOfTNs>1</CountOfTNs>
+				<userId>team</userId>
+				<lastModifiedDate>2019-11-05T13:48:43.238Z</lastModifiedDate>
+				<OrderDate>2019-11-05T13:48:43.238Z</OrderDate>
+				<OrderType>tn_option</OrderType>
+				<OrderStatus>FAILED</OrderStatus>
+				<OrderId>1-2-3-4</OrderId>
+			</TnOptionOrderSummary>
+		</TnOptionOrders>`,
+	}})
+	defer server.Close()
+	pages := api.TnOptionOrderPages(&OrdersFilter{Status: OrderStatusFailed, TelephoneNumber: "9195551212"})
+	if !pages.Next(context.Background()) {
+		t.Errorf("Failed call of TnOptionOrderPages(): %v", pages.Err())
+		return
+	}
+	page := pages.Page().(*TnOptionOrdersResponse)
+	expect(t, page.TotalCount, 1)
+	expect(t, page.Orders[0].OrderID, "1-2-3-4")
+	expect(t, page.Orders[0].OrderType, "tn_option")
+	expect(t, pages.Next(context.Background()), false)
+	expectNil(t, pages.Err())
+}
+
+func TestGetNumberDetails(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: tnsPath + "9195551212/tndetails",
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<TelephoneNumberResponse>
+			<TelephoneNumberDetails>
+				<City>RALEIGH</City>
+				<Lata>426</Lata>
+				<State>NC</State>
+				<FullNumber>9195551212</FullNumber>
+				<Tier>0</Tier>
+				<VendorId>49</VendorId>
+				<VendorName>Bandwidth CLEC</VendorName>
+				<OnNetVendor>true</OnNetVendor>
+				<RateCenter>RALEIGH</RateCenter>
+				<Status>Inservice</Status>
+				<AccountId>123</AccountId>
+				<Site>
+					<Id>12345</Id>
+					<Name>test site</Name>
+				</Site>
+				<SipPeer>
+					<PeerId>123123</PeerId>
+					<PeerName>test peer</PeerName>
+				</SipPeer>
+				<ServiceType>Voice-Messaging</ServiceType>
+				<LastModified>2019-11-05T13:48:43.238Z</LastModified>
+				<Features>
+					<E911>
+						<Status>Success</Status>
+					</E911>
+					<MessagingSettings>
+						<SmsEnabled>true</SmsEnabled>
+						<A2pState>system_default</A2pState>
+					</MessagingSettings>
+				</Features>
+			</TelephoneNumberDetails>
+		</TelephoneNumberResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.GetNumberDetails(context.Background(), "9195551212")
+	if err != nil {
+		t.Errorf("Failed call of GetNumberDetails(): %v", err)
+		return
+	}
+	expect(t, result.Status, "Inservice")
+	expect(t, result.SiteID, "12345")
+	expect(t, result.PeerName, "test peer")
+	expect(t, result.LATA, "426")
+	expect(t, result.VendorName, "Bandwidth CLEC")
+	expect(t, result.Features.E911.Status, "Success")
+	expect(t, result.Features.MessagingSettings.SmsEnabled, true)
+	expect(t, result.Features.Lidb == nil, true)
+}
+
+func TestGetNumberHistory(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/orders?page=1&size=300&tn=9195551212", accountsPath, testAccountID),
+			ContentToSend: `
+			<ResponseSelectWrapper>
+				<ListOrderIdUserIdDate>
+					<TotalCount>2</TotalCount>
+					<OrderIdUserIdDate>
+						<OrderDate>2019-11-05T13:48:43.238Z</OrderDate>
+						<OrderType>new_number</OrderType>
+						<orderId>1</orderId>
+						<OrderStatus>COMPLETE</OrderStatus>
+					</OrderIdUserIdDate>
+					<OrderIdUserIdDate>
+						<OrderDate>2019-12-05T13:48:43.238Z</OrderDate>
+						<OrderType>new_number</OrderType>
+						<orderId>3</orderId>
+						<OrderStatus>COMPLETE</OrderStatus>
+					</OrderIdUserIdDate>
+				</ListOrderIdUserIdDate>
+			</ResponseSelectWrapper>`,
+		},
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/disconnects?page=1&size=300&tn=9195551212", accountsPath, testAccountID),
+			ContentToSend: `
+			<ResponseSelectWrapper>
+				<ListOrderIdUserIdDate>
+					<TotalCount>1</TotalCount>
+					<OrderIdUserIdDate>
+						<OrderDate>2019-11-20T13:48:43.238Z</OrderDate>
+						<OrderType>disconnect</OrderType>
+						<orderId>2</orderId>
+						<OrderStatus>COMPLETE</OrderStatus>
+					</OrderIdUserIdDate>
+				</ListOrderIdUserIdDate>
+			</ResponseSelectWrapper>`,
+		},
+	})
+	defer server.Close()
+	result, err := api.GetNumberHistory(context.Background(), "9195551212")
+	if err != nil {
+		t.Errorf("Failed call of GetNumberHistory(): %v", err)
+		return
+	}
+	expect(t, len(result), 3)
+	expect(t, result[0].OrderID, "1")
+	expect(t, result[1].OrderID, "2")
+	expect(t, result[1].OrderType, "disconnect")
+	expect(t, result[2].OrderID, "3")
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/orders/1-2-3-4/history", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<OrderHistoryWrapper>
+			<OrderHistory>
+				<OrderDate>2019-11-05T13:48:43.238Z</OrderDate>
+				<Note>Order backordered - awaiting additional numbers</Note>
+				<Author>team</Author>
+				<Status>BACKORDERED</Status>
+			</OrderHistory>
+			<OrderHistory>
+				<OrderDate>2019-11-05T13:49:43.238Z</OrderDate>
+				<Note>Order completed</Note>
+				<Author>System</Author>
+				<Status>COMPLETE</Status>
+			</OrderHistory>
+		</OrderHistoryWrapper>`,
+	}})
+	defer server.Close()
+	result, err := api.GetOrderHistory(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of GetOrderHistory(): %v", err)
+		return
+	}
+	expect(t, len(result), 2)
+	expect(t, result[0].Status, OrderStatusBackordered)
+	expect(t, result[1].Status, OrderStatusComplete)
+	expect(t, result[1].Author, "System")
+}
+
+func TestGetOrderNotes(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/orders/1-2-3-4/notes", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<Notes>
+			<Note>
+				<Id>11425</Id>
+				<UserId>team</UserId>
+				<Description>Billing reviewed</Description>
+				<LastDateModifier>2019-11-05T13:48:43.238Z</LastDateModifier>
+			</Note>
+		</Notes>`,
+	}})
+	defer server.Close()
+	result, err := api.GetOrderNotes(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of GetOrderNotes(): %v", err)
+		return
+	}
+	expect(t, len(result), 1)
+	expect(t, result[0].ID, "11425")
+	expect(t, result[0].Description, "Billing reviewed")
+}
+
+func TestAddOrderNote(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/orders/1-2-3-4/notes", accountsPath, testAccountID),
+		Method:           http.MethodPut,
+		EstimatedContent: "<Note><UserId>team</UserId><Description>Billing reviewed</Description></Note>",
+		HeadersToSend: map[string]string{
+			"Location": fmt.Sprintf("https://dashboard.bandwidth.com:443/v1.0/accounts/%s/orders/1-2-3-4/notes/11425", testAccountID),
+		},
+	}})
+	defer server.Close()
+	id, err := api.AddOrderNote(context.Background(), "1-2-3-4", "team", "Billing reviewed")
+	if err != nil {
+		t.Errorf("Failed call of AddOrderNote(): %v", err)
+		return
+	}
+	expect(t, id, "11425")
+}
+
 func TestSearchNumbersByAreaCode(t *testing.T) {
 	areaCode := "510"
 	numbers := []string{"5101231234", "5101234567"}
@@ -505,3 +1123,565 @@ func TestGetDisconnect(t *testing.T) {
 	expect(t, telephoneNumbers[0], numbers[0])
 	expect(t, telephoneNumbers[1], numbers[1])
 }
+
+func TestGetPeer(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID),
+		Method:       http.MethodGet,
+		ContentToSend: fmt.Sprintf(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SipPeerResponse>
+			<SipPeer>
+				<PeerId>%s</PeerId>
+				<PeerName>test peer</PeerName>
+				<IsDefaultPeer>true</IsDefaultPeer>
+				<ShortMessagingProtocol>HTTP</ShortMessagingProtocol>
+				<VoiceHosts>
+					<Host>
+						<HostName>192.168.181.2</HostName>
+						<Port>5060</Port>
+					</Host>
+				</VoiceHosts>
+				<TerminationHosts>
+					<TerminationHost>
+						<HostName>192.168.181.3</HostName>
+						<Port>0</Port>
+						<CustomerTrafficAllowed>DOMESTIC</CustomerTrafficAllowed>
+						<DataAllowed>true</DataAllowed>
+					</TerminationHost>
+				</TerminationHosts>
+				<FinalDestinationUri>sip:+_@192.168.181.2</FinalDestinationUri>
+				<CallingName>
+					<Display>true</Display>
+					<Enforced>false</Enforced>
+				</CallingName>
+			</SipPeer>
+		</SipPeerResponse>`, peerID)}})
+	defer server.Close()
+	result, err := api.GetPeer(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of GetPeer(): %v", err)
+		return
+	}
+	expect(t, result.PeerID, peerID)
+	expect(t, result.PeerName, "test peer")
+	expect(t, result.IsDefaultPeer, true)
+	expect(t, result.VoiceHosts.Host[0], Host{HostName: "192.168.181.2", Port: 5060})
+	expect(t, result.TerminationHosts.TerminationHost[0].CustomerTrafficAllowed, "DOMESTIC")
+	expect(t, result.FinalDestinationURI, "sip:+_@192.168.181.2")
+	expect(t, result.CallingName.Display, true)
+}
+
+func TestListPeers(t *testing.T) {
+	siteID := "12345"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers", accountsPath, testAccountID, siteID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<TNSipPeersResponse>
+			<SipPeers>
+				<SipPeer>
+					<PeerId>678</PeerId>
+					<PeerName>first</PeerName>
+					<IsDefaultPeer>true</IsDefaultPeer>
+				</SipPeer>
+				<SipPeer>
+					<PeerId>679</PeerId>
+					<PeerName>second</PeerName>
+					<IsDefaultPeer>false</IsDefaultPeer>
+				</SipPeer>
+			</SipPeers>
+		</TNSipPeersResponse>`}})
+	defer server.Close()
+	result, err := api.ListPeers(context.Background(), siteID)
+	if err != nil {
+		t.Errorf("Failed call of ListPeers(): %v", err)
+		return
+	}
+	expect(t, len(result), 2)
+	expect(t, result[1].PeerID, "679")
+	expect(t, result[1].PeerName, "second")
+}
+
+func TestUpdatePeer(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID),
+		Method:           http.MethodPut,
+		EstimatedContent: `<SipPeer><PeerName>new name</PeerName><Description></Description><IsDefaultPeer>false</IsDefaultPeer><FinalDestinationUri>sip:+_@192.168.181.2</FinalDestinationUri></SipPeer>`,
+	}})
+	defer server.Close()
+	err := api.UpdatePeer(context.Background(), siteID, peerID, &SipPeer{PeerID: peerID, PeerName: "new name", FinalDestinationURI: "sip:+_@192.168.181.2"})
+	if err != nil {
+		t.Errorf("Failed call of UpdatePeer(): %v", err)
+	}
+}
+
+func TestDeletePeer(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	err := api.DeletePeer(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of DeletePeer(): %v", err)
+	}
+}
+
+func TestDeletePeerFail(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID),
+		Method:           http.MethodDelete,
+		StatusCodeToSend: http.StatusConflict,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SipPeerResponse>
+			<ResponseStatus>
+				<ErrorCode>12057</ErrorCode>
+				<Description>SIP peer has telephone numbers assigned</Description>
+			</ResponseStatus>
+		</SipPeerResponse>`,
+	}})
+	defer server.Close()
+	err := api.DeletePeer(context.Background(), siteID, peerID)
+	expect(t, err.(*APIError).Code, "12057")
+}
+
+func TestCreateSite(t *testing.T) {
+	siteID := "12345"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: `<Site><Name>test site</Name><Address><HouseNumber>900</HouseNumber><StreetName>Main Campus</StreetName><StreetSuffix>Dr</StreetSuffix><City>Raleigh</City><StateCode>NC</StateCode><Zip>27606</Zip><AddressType>Service</AddressType></Address></Site>`,
+		HeadersToSend: map[string]string{
+			"Location": fmt.Sprintf("https://dashboard.bandwidth.com:443/v1.0/accounts/%s/sites/%s", testAccountID, siteID),
+		},
+	}})
+	defer server.Close()
+	result, err := api.CreateSite(context.Background(), &Site{Name: "test site", Address: &Address{
+		HouseNumber:  "900",
+		StreetName:   "Main Campus",
+		StreetSuffix: "Dr",
+		City:         "Raleigh",
+		StateCode:    "NC",
+		Zip:          "27606",
+		AddressType:  "Service",
+	}})
+	if err != nil {
+		t.Errorf("Failed call of CreateSite(): %v", err)
+		return
+	}
+	expect(t, result, siteID)
+}
+
+func TestCreateSiteFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+		Method:       http.MethodPost,
+	}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateSite(context.Background(), &Site{Name: "test site"})
+	})
+}
+
+func TestGetSite(t *testing.T) {
+	siteID := "12345"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s", accountsPath, testAccountID, siteID),
+		Method:       http.MethodGet,
+		ContentToSend: fmt.Sprintf(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SiteResponse>
+			<Site>
+				<Id>%s</Id>
+				<Name>test site</Name>
+				<Description>description</Description>
+				<Address>
+					<HouseNumber>900</HouseNumber>
+					<StreetName>Main Campus</StreetName>
+					<City>Raleigh</City>
+					<StateCode>NC</StateCode>
+					<Zip>27606</Zip>
+					<Country>United States</Country>
+					<AddressType>Service</AddressType>
+				</Address>
+			</Site>
+		</SiteResponse>`, siteID)}})
+	defer server.Close()
+	result, err := api.GetSite(context.Background(), siteID)
+	if err != nil {
+		t.Errorf("Failed call of GetSite(): %v", err)
+		return
+	}
+	expect(t, result.ID, siteID)
+	expect(t, result.Name, "test site")
+	expect(t, result.Address.City, "Raleigh")
+}
+
+func TestListSites(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SitesResponse>
+			<Sites>
+				<Site>
+					<Id>12345</Id>
+					<Name>first</Name>
+				</Site>
+				<Site>
+					<Id>12346</Id>
+					<Name>second</Name>
+				</Site>
+			</Sites>
+		</SitesResponse>`}})
+	defer server.Close()
+	result, err := api.ListSites(context.Background())
+	if err != nil {
+		t.Errorf("Failed call of ListSites(): %v", err)
+		return
+	}
+	expect(t, len(result), 2)
+	expect(t, result[1].ID, "12346")
+}
+
+func TestUpdateSite(t *testing.T) {
+	siteID := "12345"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s", accountsPath, testAccountID, siteID),
+		Method:           http.MethodPut,
+		EstimatedContent: `<Site><Name>new name</Name><Description>description</Description></Site>`,
+	}})
+	defer server.Close()
+	err := api.UpdateSite(context.Background(), siteID, &Site{ID: siteID, Name: "new name", Description: "description"})
+	if err != nil {
+		t.Errorf("Failed call of UpdateSite(): %v", err)
+	}
+}
+
+func TestDeleteSite(t *testing.T) {
+	siteID := "12345"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s", accountsPath, testAccountID, siteID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	err := api.DeleteSite(context.Background(), siteID)
+	if err != nil {
+		t.Errorf("Failed call of DeleteSite(): %v", err)
+	}
+}
+
+func TestUpdateSMSFeature(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/sms", accountsPath, testAccountID, siteID, peerID),
+		Method:           http.MethodPut,
+		EstimatedContent: `<SipPeerSmsFeature><SipPeerSmsFeatureSettings><TollFree>false</TollFree><ShortCode>false</ShortCode><A2pLongCode>DefaultOn</A2pLongCode><Protocol>HTTP</Protocol><Zone1>true</Zone1><Zone2>true</Zone2><Zone3>false</Zone3><Zone4>false</Zone4><Zone5>false</Zone5></SipPeerSmsFeatureSettings><HttpSettings><ProxyPeerId>1234</ProxyPeerId></HttpSettings><A2pSettings><Action>asSpecified</Action><CampaignId>CABC123</CampaignId></A2pSettings></SipPeerSmsFeature>`,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SipPeerSmsFeatureResponse>
+			<SipPeerSmsFeature>
+				<SipPeerSmsFeatureSettings>
+					<A2pLongCode>DefaultOn</A2pLongCode>
+					<Zone2>true</Zone2>
+				</SipPeerSmsFeatureSettings>
+			</SipPeerSmsFeature>
+		</SipPeerSmsFeatureResponse>`}})
+	defer server.Close()
+	result, err := api.UpdateSMSFeature(context.Background(), siteID, peerID, &SipPeerSmsFeature{
+		SipPeerSmsFeatureSettings: SipPeerSmsFeatureSettings{
+			A2pLongCode: "DefaultOn",
+			Protocol:    "HTTP",
+			Zone1:       true,
+			Zone2:       true,
+		},
+		HttpSettings: HttpSettings{ProxyPeerId: 1234},
+		A2pSettings:  &A2pSettings{Action: "asSpecified", CampaignID: "CABC123"},
+	})
+	if err != nil {
+		t.Errorf("Failed call of UpdateSMSFeature(): %v", err)
+		return
+	}
+	expect(t, result.SipPeerSmsFeature.SipPeerSmsFeatureSettings.Zone2, true)
+}
+
+func TestGetSMSFeature(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/sms", accountsPath, testAccountID, siteID, peerID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SipPeerSmsFeatureResponse>
+			<SipPeerSmsFeature>
+				<SipPeerSmsFeatureSettings>
+					<TollFree>true</TollFree>
+					<ShortCode>false</ShortCode>
+					<A2pLongCode>DefaultOff</A2pLongCode>
+					<Protocol>HTTP</Protocol>
+					<Zone1>true</Zone1>
+				</SipPeerSmsFeatureSettings>
+				<HttpSettings>
+					<ProxyPeerId>1234</ProxyPeerId>
+				</HttpSettings>
+			</SipPeerSmsFeature>
+		</SipPeerSmsFeatureResponse>`}})
+	defer server.Close()
+	result, err := api.GetSMSFeature(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of GetSMSFeature(): %v", err)
+		return
+	}
+	expect(t, result.SipPeerSmsFeature.SipPeerSmsFeatureSettings.TollFree, true)
+	expect(t, result.SipPeerSmsFeature.HttpSettings.ProxyPeerId, 1234)
+}
+
+func TestDisableSMS(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/sms", accountsPath, testAccountID, siteID, peerID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	err := api.DisableSMS(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of DisableSMS(): %v", err)
+	}
+}
+
+func TestUpdateMMSFeature(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/mms", accountsPath, testAccountID, siteID, peerID),
+		Method:           http.MethodPut,
+		EstimatedContent: `<MmsFeature><MmsSettings><Protocol>MM4</Protocol></MmsSettings><Protocols><MM4><Tls>OFF</Tls><MmsMM4TermHosts><TermHost><HostName>206.107.248.58</HostName></TermHost></MmsMM4TermHosts><MmsMM4OrigHosts><OrigHost><HostName>mmsc.example.com</HostName><Port>25</Port></OrigHost></MmsMM4OrigHosts></MM4></Protocols></MmsFeature>`,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<MmsFeatureResponse>
+			<MmsFeature>
+				<MmsSettings>
+					<Protocol>MM4</Protocol>
+				</MmsSettings>
+			</MmsFeature>
+		</MmsFeatureResponse>`}})
+	defer server.Close()
+	result, err := api.UpdateMMSFeature(context.Background(), siteID, peerID, &MmsFeature{
+		MmsSettings: MmsSettings{Protocol: "MM4"},
+		Protocols: Protocols{MM4: &MM4Protocol{
+			Tls:       "OFF",
+			TermHosts: []Host{Host{HostName: "206.107.248.58"}},
+			OrigHosts: []Host{Host{HostName: "mmsc.example.com", Port: 25}},
+		}},
+	})
+	if err != nil {
+		t.Errorf("Failed call of UpdateMMSFeature(): %v", err)
+		return
+	}
+	expect(t, result.MmsFeature.MmsSettings.Protocol, "MM4")
+}
+
+func TestGetMMSFeature(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/mms", accountsPath, testAccountID, siteID, peerID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<MmsFeatureResponse>
+			<MmsFeature>
+				<MmsSettings>
+					<Protocol>MM4</Protocol>
+				</MmsSettings>
+				<Protocols>
+					<MM4>
+						<Tls>OFF</Tls>
+						<MmsMM4TermHosts>
+							<TermHost>
+								<HostName>206.107.248.58</HostName>
+							</TermHost>
+						</MmsMM4TermHosts>
+					</MM4>
+				</Protocols>
+			</MmsFeature>
+		</MmsFeatureResponse>`}})
+	defer server.Close()
+	result, err := api.GetMMSFeature(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of GetMMSFeature(): %v", err)
+		return
+	}
+	expect(t, result.MmsFeature.MmsSettings.Protocol, "MM4")
+	expect(t, result.MmsFeature.Protocols.MM4.TermHosts[0].HostName, "206.107.248.58")
+}
+
+func TestDisableMMS(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/mms", accountsPath, testAccountID, siteID, peerID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	err := api.DisableMMS(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of DisableMMS(): %v", err)
+	}
+}
+
+func TestDisassociateApplication(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/applicationSettings", accountsPath, testAccountID, siteID, peerID),
+		Method:           http.MethodPut,
+		EstimatedContent: `<ApplicationsSettings>REMOVE</ApplicationsSettings>`,
+	}})
+	defer server.Close()
+	err := api.DisassociateApplication(context.Background(), siteID, peerID)
+	if err != nil {
+		t.Errorf("Failed call of DisassociateApplication(): %v", err)
+	}
+}
+
+func TestCreateMessagingApplication(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/applications", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: `<Application><ServiceType>Messaging-V2</ServiceType><AppName>test app</AppName><MsgCallbackUrl>https://example.com/callback</MsgCallbackUrl><CallbackCreds><UserId>user</UserId><Password>secret</Password></CallbackCreds><RequestedCallbackTypes><CallbackType>message-delivered</CallbackType><CallbackType>message-failed</CallbackType></RequestedCallbackTypes></Application>`,
+		ContentToSend: fmt.Sprintf(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<ApplicationProvisioningResponse>
+			<Application>
+				<ApplicationId>%s</ApplicationId>
+				<ServiceType>Messaging-V2</ServiceType>
+				<AppName>test app</AppName>
+				<MsgCallbackUrl>https://example.com/callback</MsgCallbackUrl>
+				<CallbackCreds>
+					<UserId>user</UserId>
+				</CallbackCreds>
+			</Application>
+		</ApplicationProvisioningResponse>`, testApplicationID)}})
+	defer server.Close()
+	result, err := api.CreateMessagingApplication(context.Background(), &Application{
+		AppName:                "test app",
+		MsgCallbackURL:         "https://example.com/callback",
+		CallbackCreds:          &CallbackCreds{UserID: "user", Password: "secret"},
+		RequestedCallbackTypes: &CallbackTypes{CallbackType: []string{MessageDelivered, MessageFailed}},
+	})
+	if err != nil {
+		t.Errorf("Failed call of CreateMessagingApplication(): %v", err)
+		return
+	}
+	expect(t, result.ApplicationID, testApplicationID)
+	expect(t, result.CallbackCreds.UserID, "user")
+}
+
+func TestGetApplication(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/applications/%s", accountsPath, testAccountID, testApplicationID),
+		Method:       http.MethodGet,
+		ContentToSend: fmt.Sprintf(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<ApplicationProvisioningResponse>
+			<Application>
+				<ApplicationId>%s</ApplicationId>
+				<ServiceType>Messaging-V2</ServiceType>
+				<AppName>test app</AppName>
+				<MsgCallbackUrl>https://example.com/callback</MsgCallbackUrl>
+				<RequestedCallbackTypes>
+					<CallbackType>message-delivered</CallbackType>
+				</RequestedCallbackTypes>
+			</Application>
+		</ApplicationProvisioningResponse>`, testApplicationID)}})
+	defer server.Close()
+	result, err := api.GetApplication(context.Background(), testApplicationID)
+	if err != nil {
+		t.Errorf("Failed call of GetApplication(): %v", err)
+		return
+	}
+	expect(t, result.AppName, "test app")
+	expect(t, result.RequestedCallbackTypes.CallbackType, []string{MessageDelivered})
+}
+
+func TestListApplications(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/applications", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<ApplicationProvisioningResponse>
+			<ApplicationList>
+				<Application>
+					<ApplicationId>1</ApplicationId>
+					<AppName>first</AppName>
+				</Application>
+				<Application>
+					<ApplicationId>2</ApplicationId>
+					<AppName>second</AppName>
+				</Application>
+			</ApplicationList>
+		</ApplicationProvisioningResponse>`}})
+	defer server.Close()
+	result, err := api.ListApplications(context.Background())
+	if err != nil {
+		t.Errorf("Failed call of ListApplications(): %v", err)
+		return
+	}
+	expect(t, len(result), 2)
+	expect(t, result[1].AppName, "second")
+}
+
+func TestUpdateApplication(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/applications/%s", accountsPath, testAccountID, testApplicationID),
+		Method:           http.MethodPut,
+		EstimatedContent: `<Application><ServiceType>Messaging-V2</ServiceType><AppName>new name</AppName><MsgCallbackUrl>https://example.com/new</MsgCallbackUrl></Application>`,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<ApplicationProvisioningResponse>
+			<Application>
+				<AppName>new name</AppName>
+			</Application>
+		</ApplicationProvisioningResponse>`}})
+	defer server.Close()
+	result, err := api.UpdateApplication(context.Background(), testApplicationID, &Application{
+		ApplicationID:  testApplicationID,
+		ServiceType:    MessagingServiceType,
+		AppName:        "new name",
+		MsgCallbackURL: "https://example.com/new",
+	})
+	if err != nil {
+		t.Errorf("Failed call of UpdateApplication(): %v", err)
+		return
+	}
+	expect(t, result.AppName, "new name")
+}
+
+func TestDeleteApplication(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/applications/%s", accountsPath, testAccountID, testApplicationID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	err := api.DeleteApplication(context.Background(), testApplicationID)
+	if err != nil {
+		t.Errorf("Failed call of DeleteApplication(): %v", err)
+	}
+}
@@ -38,12 +38,137 @@ type SipPeerTelephoneNumbers struct {
 // SipPeerTelephoneNumbersResponse is the response to fetching sip-peers.
 type SipPeerTelephoneNumbersResponse struct {
 	Peers SipPeerTelephoneNumbers `xml:"SipPeerTelephoneNumbers"`
+	Links Links
 }
 
+func (r *SipPeerTelephoneNumbersResponse) pageLinks() Links {
+	return r.Links
+}
+
+// InServiceNumbersFilter filters the in-service numbers. Empty fields are ignored.
+type InServiceNumbersFilter struct {
+	AreaCode   string
+	NpaNxx     string
+	State      string
+	Lata       string
+	RateCenter string
+	// PageSize is the number of numbers per page (default 500).
+	PageSize int
+}
+
+// InServiceNumbersResponse is a page of the in-service numbers.
+type InServiceNumbersResponse struct {
+	XMLName          xml.Name `xml:"TNs"`
+	TotalCount       int
+	Links            Links
+	TelephoneNumbers []string `xml:"TelephoneNumbers>TelephoneNumber"`
+}
+
+func (r *InServiceNumbersResponse) pageLinks() Links {
+	return r.Links
+}
+
+// Address is a postal address.
+type Address struct {
+	HouseNumber     string `xml:",omitempty"`
+	HousePrefix     string `xml:",omitempty"`
+	HouseSuffix     string `xml:",omitempty"`
+	PreDirectional  string `xml:",omitempty"`
+	StreetName      string `xml:",omitempty"`
+	StreetSuffix    string `xml:",omitempty"`
+	PostDirectional string `xml:",omitempty"`
+	AddressLine2    string `xml:",omitempty"`
+	City            string `xml:",omitempty"`
+	StateCode       string `xml:",omitempty"`
+	Zip             string `xml:",omitempty"`
+	PlusFour        string `xml:",omitempty"`
+	County          string `xml:",omitempty"`
+	Country         string `xml:",omitempty"`
+	// AddressType is Service or Billing.
+	AddressType string `xml:",omitempty"`
+}
+
+// Site is a site (aka sub-account) of the account.
+type Site struct {
+	// ID is set by the API and ignored on create/update.
+	ID                 string `xml:"Id,omitempty"`
+	Name               string
+	Description        string   `xml:",omitempty"`
+	CustomerProvidedID string   `xml:",omitempty"`
+	CustomerName       string   `xml:",omitempty"`
+	Address            *Address `xml:",omitempty"`
+}
+
+// SiteResponse is the response to fetching a site.
+type SiteResponse struct {
+	Site Site
+}
+
+// SitesResponse is the response to listing sites.
+type SitesResponse struct {
+	Sites []Site `xml:"Sites>Site"`
+}
+
+// SipPeer is a sip-peer (aka location) of the site.
 type SipPeer struct {
+	// PeerID is set by the API and ignored on create/update.
+	PeerID        string `xml:"PeerId,omitempty"`
 	PeerName      string
 	Description   string
 	IsDefaultPeer bool
+	// ShortMessagingProtocol is the messaging protocol (HTTP or SMPP).
+	ShortMessagingProtocol string `xml:",omitempty"`
+	// VoiceProtocol is the call protocol of the origination (SIP or HTTP).
+	VoiceProtocol string `xml:",omitempty"`
+	// VoiceHosts are the origination hosts receiving the calls.
+	VoiceHosts *Hosts `xml:",omitempty"`
+	// SmsHosts are the hosts receiving the messages (SMPP).
+	SmsHosts *Hosts `xml:",omitempty"`
+	// TerminationHosts are the hosts allowed to send the calls.
+	TerminationHosts *TerminationHosts `xml:",omitempty"`
+	// FinalDestinationURI is the SIP URI the calls are forwarded to.
+	FinalDestinationURI string       `xml:"FinalDestinationUri,omitempty"`
+	CallingName         *CallingName `xml:",omitempty"`
+}
+
+// Host is an origination (voice or sms) host.
+type Host struct {
+	HostName string
+	Port     int `xml:",omitempty"`
+}
+
+// Hosts is a list of hosts.
+type Hosts struct {
+	Host []Host
+}
+
+// TerminationHost is a host allowed to terminate calls via the sip-peer.
+type TerminationHost struct {
+	HostName               string
+	Port                   int    `xml:",omitempty"`
+	CustomerTrafficAllowed string `xml:",omitempty"`
+	DataAllowed            bool
+}
+
+// TerminationHosts is a list of termination hosts.
+type TerminationHosts struct {
+	TerminationHost []TerminationHost
+}
+
+// CallingName contains the calling name (CNAM) settings.
+type CallingName struct {
+	Display  bool
+	Enforced bool
+}
+
+// SipPeerResponse is the response to fetching a sip-peer.
+type SipPeerResponse struct {
+	SipPeer SipPeer
+}
+
+// SipPeersResponse is the response to listing sip-peers of the site.
+type SipPeersResponse struct {
+	SipPeers []SipPeer `xml:"SipPeers>SipPeer"`
 }
 
 type HttpSettings struct {
@@ -61,9 +186,19 @@ type SipPeerSmsFeatureSettings struct {
 	Zone4       bool
 	Zone5       bool
 }
+
+// A2pSettings are the application-to-person (10DLC) messaging settings.
+type A2pSettings struct {
+	// Action is asSpecified, off, keep or delete.
+	Action       string `xml:",omitempty"`
+	MessageClass string `xml:",omitempty"`
+	CampaignID   string `xml:"CampaignId,omitempty"`
+}
+
 type SipPeerSmsFeature struct {
 	SipPeerSmsFeatureSettings SipPeerSmsFeatureSettings
 	HttpSettings              HttpSettings
+	A2pSettings               *A2pSettings `xml:",omitempty"`
 }
 
 type SipPeerSmsFeatureResponse struct {
@@ -71,14 +206,35 @@ type SipPeerSmsFeatureResponse struct {
 }
 
 type MmsSettings struct {
+	// Protocol is HTTP or MM4.
 	Protocol string
 }
 type HTTPProtocol struct {
 	HttpSettings HttpSettings
 }
+
+// MM4Protocol are the settings of MMS over MM4.
+type MM4Protocol struct {
+	// Tls is ON or OFF.
+	Tls       string `xml:",omitempty"`
+	TermHosts []Host `xml:"MmsMM4TermHosts>TermHost"`
+	OrigHosts []Host `xml:"MmsMM4OrigHosts>OrigHost"`
+}
+
 type Protocols struct {
 	HTTP HTTPProtocol
+	MM4  *MM4Protocol `xml:",omitempty"`
+}
+
+// MarshalXML omits the empty HTTP settings when MM4 is set, so the peer can be switched to MM4.
+func (p Protocols) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if p.MM4 != nil && p.HTTP == (HTTPProtocol{}) {
+		return e.EncodeElement(struct{ MM4 *MM4Protocol }{p.MM4}, start)
+	}
+	type protocols Protocols
+	return e.EncodeElement(protocols(p), start)
 }
+
 type MmsFeature struct {
 	MmsSettings MmsSettings
 	Protocols   Protocols
@@ -96,6 +252,43 @@ type ApplicationsSettingsResponse struct {
 	ApplicationsSettings ApplicationsSettings
 }
 
+type removeApplicationsSettings struct {
+	XMLName xml.Name `xml:"ApplicationsSettings"`
+	Value   string   `xml:",chardata"`
+}
+
+// MessagingServiceType is the service type of messaging (v2) applications.
+const MessagingServiceType = "Messaging-V2"
+
+// CallbackCreds are the credentials Bandwidth uses to authenticate the callbacks (Basic-Auth).
+type CallbackCreds struct {
+	UserID   string `xml:"UserId"`
+	Password string
+}
+
+// Application is a messaging or voice application.
+type Application struct {
+	// ApplicationID is set by the API and ignored on create/update.
+	ApplicationID  string `xml:"ApplicationId,omitempty"`
+	ServiceType    string
+	AppName        string
+	MsgCallbackURL string         `xml:"MsgCallbackUrl,omitempty"`
+	CallbackCreds  *CallbackCreds `xml:",omitempty"`
+	// RequestedCallbackTypes are the callback events sent to MsgCallbackURL.
+	RequestedCallbackTypes *CallbackTypes `xml:",omitempty"`
+}
+
+// CallbackTypes is a list of the callback event types (like message-delivered).
+type CallbackTypes struct {
+	CallbackType []string
+}
+
+// ApplicationProvisioningResponse is the response to fetching or changing applications.
+type ApplicationProvisioningResponse struct {
+	Application     Application
+	ApplicationList []Application `xml:"ApplicationList>Application"`
+}
+
 type OrderRequest struct {
 	Name           string
 	SiteID         string `xml:"SiteId"`
@@ -112,8 +305,122 @@ type AreaCodeOrder struct {
 	AreaCodeSearchAndOrderType AreaCodeSearchAndOrderType
 }
 
+// NumberOrder is an order of numbers. Set exactly one of the order types.
+type NumberOrder struct {
+	XMLName            xml.Name `xml:"Order"`
+	Name               string   `xml:",omitempty"`
+	SiteID             string   `xml:"SiteId"`
+	PeerID             string   `xml:"PeerId,omitempty"`
+	PartialAllowed     bool
+	BackOrderRequested bool   `xml:",omitempty"`
+	CustomerOrderID    string `xml:"CustomerOrderId,omitempty"`
+
+	ExistingTelephoneNumberOrderType   *ExistingTelephoneNumberOrderType   `xml:",omitempty"`
+	AreaCodeSearchAndOrderType         *AreaCodeSearchAndOrderType         `xml:",omitempty"`
+	NPANXXSearchAndOrderType           *NPANXXSearchAndOrderType           `xml:",omitempty"`
+	RateCenterSearchAndOrderType       *RateCenterSearchAndOrderType       `xml:",omitempty"`
+	ZIPSearchAndOrderType              *ZIPSearchAndOrderType              `xml:",omitempty"`
+	CitySearchAndOrderType             *CitySearchAndOrderType             `xml:",omitempty"`
+	StateSearchAndOrderType            *StateSearchAndOrderType            `xml:",omitempty"`
+	LATASearchAndOrderType             *LATASearchAndOrderType             `xml:",omitempty"`
+	CombinedSearchAndOrderType         *CombinedSearchAndOrderType         `xml:",omitempty"`
+	TollFreeVanitySearchAndOrderType   *TollFreeVanitySearchAndOrderType   `xml:",omitempty"`
+	TollFreeWildCharSearchAndOrderType *TollFreeWildCharSearchAndOrderType `xml:",omitempty"`
+}
+
+// ExistingTelephoneNumberOrderType orders the given (previously found) numbers.
+type ExistingTelephoneNumberOrderType struct {
+	TelephoneNumberList TelephoneNumberList
+	// ReservationIDList are the reservations of the numbers (if reserved by ReserveNumber).
+	ReservationIDList *ReservationIDList `xml:"ReservationIdList,omitempty"`
+}
+
+// ReservationIDList is a list of the reservation IDs.
+type ReservationIDList struct {
+	ReservationID []string `xml:"ReservationId"`
+}
+
+// Reservation holds the number so it can't be ordered by someone else.
+type Reservation struct {
+	XMLName       xml.Name `xml:"Reservation"`
+	ReservationID string   `xml:"ReservationId,omitempty"`
+	AccountID     string   `xml:"AccountId,omitempty"`
+	// ReservationExpires is the remaining time of the reservation in seconds.
+	ReservationExpires int `xml:",omitempty"`
+	ReservedTn         string
+}
+
+// ReservationResponse is the response of GetReservation.
+type ReservationResponse struct {
+	Reservation Reservation
+}
+
+// NPANXXSearchAndOrderType orders numbers in the NPA-NXX.
+type NPANXXSearchAndOrderType struct {
+	NpaNxx         string
+	EnableTNDetail bool `xml:",omitempty"`
+	EnableLCA      bool `xml:",omitempty"`
+	Quantity       int
+}
+
+// RateCenterSearchAndOrderType orders numbers in the rate center.
+type RateCenterSearchAndOrderType struct {
+	RateCenter string
+	State      string
+	Quantity   int
+}
+
+// ZIPSearchAndOrderType orders numbers in the ZIP code.
+type ZIPSearchAndOrderType struct {
+	Zip      string
+	Quantity int
+}
+
+// CitySearchAndOrderType orders numbers in the city.
+type CitySearchAndOrderType struct {
+	City     string
+	State    string
+	Quantity int
+}
+
+// StateSearchAndOrderType orders numbers in the state.
+type StateSearchAndOrderType struct {
+	State    string
+	Quantity int
+}
+
+// LATASearchAndOrderType orders numbers in the LATA.
+type LATASearchAndOrderType struct {
+	Lata     string
+	Quantity int
+}
+
+// CombinedSearchAndOrderType orders numbers matching all the given criteria.
+type CombinedSearchAndOrderType struct {
+	Quantity    int
+	AreaCode    string `xml:",omitempty"`
+	RateCenter  string `xml:",omitempty"`
+	NpaNxx      string `xml:",omitempty"`
+	NpaNxxx     string `xml:",omitempty"`
+	State       string `xml:",omitempty"`
+	City        string `xml:",omitempty"`
+	Zip         string `xml:",omitempty"`
+	Lata        string `xml:",omitempty"`
+	LocalVanity string `xml:",omitempty"`
+	EndsIn      bool   `xml:",omitempty"`
+	EnableLCA   bool   `xml:",omitempty"`
+}
+
+// TollFreeVanitySearchAndOrderType orders toll-free numbers containing the vanity pattern.
+type TollFreeVanitySearchAndOrderType struct {
+	TollFreeVanity string
+	Quantity       int
+}
+
 type OrderResponseOrder struct {
 	OrderCreated               time.Time
+	Name                       string
+	CustomerOrderID            string `xml:"CustomerOrderId"`
 	PeerID                     string `xml:"PeerId"`
 	BackOrderRequested         bool
 	ID                         string `xml:"id"`
@@ -126,13 +433,423 @@ type OrderResponse struct {
 	Order       OrderResponseOrder `xml:"Order"`
 	OrderStatus string
 	// Only relevant if OrderStatus == COMPLETE
-	CompletedNumbers CompletedNumbers
+	CompletedNumbers  CompletedNumbers
+	CompletedQuantity int
+	FailedNumbers     FailedNumbers
+	FailedQuantity    int
+	Summary           string
+	// ErrorList contains the reasons the numbers failed.
+	ErrorList []ErrorDetail `xml:"ErrorList>Error"`
+}
+
+// OrdersFilter filters the orders. Empty fields are ignored.
+type OrdersFilter struct {
+	// Status is the order status (like OrderStatusComplete).
+	Status string
+	// StartDate and EndDate limit the order dates (only the dates are used).
+	StartDate, EndDate time.Time
+	CustomerOrderID    string
+	// TelephoneNumber returns only the orders of the number.
+	TelephoneNumber string
+	// PageSize is the number of orders per page (default 300).
+	PageSize int
+}
+
+// OrderSummary is an order in the list of orders.
+type OrderSummary struct {
+	AccountID        string `xml:"accountId"`
+	CountOfTNs       int
+	CustomerOrderID  string    `xml:"CustomerOrderId"`
+	UserID           string    `xml:"userId"`
+	LastModifiedDate time.Time `xml:"lastModifiedDate"`
+	OrderDate        time.Time
+	OrderType        string
+	OrderID          string `xml:"orderId"`
+	OrderStatus      string
+}
+
+// OrdersResponse is a page of the orders.
+type OrdersResponse struct {
+	XMLName    xml.Name       `xml:"ResponseSelectWrapper"`
+	TotalCount int            `xml:"ListOrderIdUserIdDate>TotalCount"`
+	Links      Links          `xml:"ListOrderIdUserIdDate>Links"`
+	Orders     []OrderSummary `xml:"ListOrderIdUserIdDate>OrderIdUserIdDate"`
+}
+
+func (r *OrdersResponse) pageLinks() Links {
+	return r.Links
+}
+
+// Values of the on/off TN options.
+const (
+	TnOptionOn  = "on"
+	TnOptionOff = "off"
+)
+
+// TnOptionGroup are the settings applied to the numbers. Empty settings are not changed.
+type TnOptionGroup struct {
+	PortOutPasscode string `xml:",omitempty"`
+	// Sms is TnOptionOn or TnOptionOff.
+	Sms         string       `xml:",omitempty"`
+	A2pSettings *A2pSettings `xml:",omitempty"`
+	// CallingNameDisplay is TnOptionOn or TnOptionOff.
+	CallingNameDisplay     string `xml:",omitempty"`
+	FinalDestinationNumber string `xml:",omitempty"`
+	CallForward            string `xml:",omitempty"`
+	TelephoneNumbers       TelephoneNumberList
+}
+
+// TnOptionOrder is the order changing the settings of the individual numbers.
+type TnOptionOrder struct {
+	XMLName         xml.Name        `xml:"TnOptionOrder"`
+	CustomerOrderID string          `xml:"CustomerOrderId,omitempty"`
+	TnOptionGroups  []TnOptionGroup `xml:"TnOptionGroups>TnOptionGroup"`
+}
+
+// TnOptionOrderDetails is the state of the TN option order.
+type TnOptionOrderDetails struct {
+	OrderID          string `xml:"OrderId"`
+	CustomerOrderID  string `xml:"CustomerOrderId"`
+	AccountID        string `xml:"AccountId"`
+	CreatedByUser    string
+	OrderCreateDate  time.Time
+	LastModifiedDate time.Time
+	// ProcessingStatus is RECEIVED, PROCESSING, COMPLETE, PARTIAL or FAILED.
+	ProcessingStatus string
+	TnOptionGroups   []TnOptionGroup `xml:"TnOptionGroups>TnOptionGroup"`
+	ErrorList        []ErrorDetail   `xml:"ErrorList>Error"`
+	Warnings         []ErrorDetail   `xml:"Warnings>Warning"`
+}
+
+// TnOptionOrderResponse is the response of SubmitTnOptionOrder.
+type TnOptionOrderResponse struct {
+	TnOptionOrder TnOptionOrderDetails
+}
+
+// TnOptionOrderSummary is a TN option order in the list of orders.
+type TnOptionOrderSummary struct {
+	OrderID          string `xml:"OrderId"`
+	AccountID        string `xml:"accountId"`
+	CountOfTNs       int
+	UserID           string    `xml:"userId"`
+	LastModifiedDate time.Time `xml:"lastModifiedDate"`
+	OrderDate        time.Time
+	OrderType        string
+	OrderStatus      string
+}
+
+// TnOptionOrdersResponse is a page of the TN option orders.
+type TnOptionOrdersResponse struct {
+	XMLName    xml.Name `xml:"TnOptionOrders"`
+	TotalCount int
+	Links      Links
+	Orders     []TnOptionOrderSummary `xml:"TnOptionOrderSummary"`
+}
+
+func (r *TnOptionOrdersResponse) pageLinks() Links {
+	return r.Links
+}
+
+// Actions of the E911 orders.
+const (
+	E911Upsert = "Upsert"
+	E911Delete = "Delete"
+)
+
+// AlternateEndUserIdentifier is an endpoint (like a softphone) the E911 address is bound to.
+type AlternateEndUserIdentifier struct {
+	Identifier        string
+	CallbackNumber    string `xml:",omitempty"`
+	PreferredLanguage string `xml:",omitempty"`
+}
+
+// AlternateEndUserIdentifiers is a list of the endpoints.
+type AlternateEndUserIdentifiers struct {
+	AlternateEndUserIdentifier []AlternateEndUserIdentifier
+}
+
+// E911Order binds the emergency address to the numbers and/or endpoints.
+type E911Order struct {
+	XMLName                     xml.Name `xml:"E911Order"`
+	CustomerOrderID             string   `xml:"CustomerOrderId,omitempty"`
+	Address                     Address
+	CallerName                  string                       `xml:",omitempty"`
+	TelephoneNumbers            *TelephoneNumberList         `xml:",omitempty"`
+	AlternateEndUserIdentifiers *AlternateEndUserIdentifiers `xml:",omitempty"`
+	// E911ServiceAction is E911Upsert or E911Delete.
+	E911ServiceAction string
+}
+
+// E911OrderDetails is the state of the E911 order.
+type E911OrderDetails struct {
+	OrderID          string `xml:"OrderId"`
+	CustomerOrderID  string `xml:"CustomerOrderId"`
+	CreatedByUser    string
+	OrderCreateDate  time.Time
+	LastModifiedDate time.Time
+	// ProcessingStatus is RECEIVED, PROCESSING, COMPLETE, PARTIAL or FAILED.
+	ProcessingStatus            string
+	Address                     Address
+	CallerName                  string
+	TelephoneNumbers            TelephoneNumberList
+	AlternateEndUserIdentifiers []AlternateEndUserIdentifier `xml:"AlternateEndUserIdentifiers>AlternateEndUserIdentifier"`
+	E911ServiceAction           string
+	ErrorList                   []ErrorDetail `xml:"ErrorList>Error"`
+}
+
+// E911OrderResponse is the response of CreateE911Order.
+type E911OrderResponse struct {
+	E911Order E911OrderDetails
+}
+
+// geocodeRequest is the address to validate.
+type geocodeRequest struct {
+	XMLName xml.Name `xml:"RequestAddress"`
+	Address
+}
+
+// geocodeResponse is the validated (geocoded) address.
+type geocodeResponse struct {
+	GeocodedAddress *Address
+}
+
+// TelephoneNumberDetails are the details of the number.
+type TelephoneNumberDetails struct {
+	FullNumber  string
+	Status      string
+	AccountID   string `xml:"AccountId"`
+	SiteID      string `xml:"Site>Id"`
+	SiteName    string `xml:"Site>Name"`
+	PeerID      string `xml:"SipPeer>PeerId"`
+	PeerName    string `xml:"SipPeer>PeerName"`
+	City        string
+	State       string
+	RateCenter  string
+	LATA        string `xml:"Lata"`
+	Tier        string
+	VendorID    string `xml:"VendorId"`
+	VendorName  string
+	OnNetVendor bool
+	ServiceType string
+	// LastModified is the time of the last change of the number.
+	LastModified time.Time
+	Features     NumberFeatures
+}
+
+// NumberFeatures are the features enabled on the number.
+type NumberFeatures struct {
+	E911              *FeatureStatus           `xml:",omitempty"`
+	Lidb              *LidbFeature             `xml:",omitempty"`
+	Dlda              *DldaFeature             `xml:",omitempty"`
+	MessagingSettings *NumberMessagingSettings `xml:",omitempty"`
+}
+
+// FeatureStatus is the status of the number feature.
+type FeatureStatus struct {
+	Status string
+}
+
+// LidbFeature is the caller name (CNAM) of the number.
+type LidbFeature struct {
+	Status                string
+	SubscriberInformation string
+	UseType               string
+	Visibility            string
+}
+
+// DldaFeature is the directory listing of the number.
+type DldaFeature struct {
+	Status         string
+	SubscriberType string
+	ListingType    string
+}
+
+// NumberMessagingSettings are the messaging settings of the number.
+type NumberMessagingSettings struct {
+	SmsEnabled bool
+	A2pState   string
+}
+
+// TelephoneNumberDetailsResponse is the response of GetNumberDetails.
+type TelephoneNumberDetailsResponse struct {
+	XMLName                xml.Name `xml:"TelephoneNumberResponse"`
+	TelephoneNumberDetails TelephoneNumberDetails
+}
+
+// OrderHistoryItem is a change of the order status.
+type OrderHistoryItem struct {
+	OrderDate time.Time
+	Note      string
+	Author    string
+	Status    string
+}
+
+// OrderHistoryResponse is the response of GetOrderHistory.
+type OrderHistoryResponse struct {
+	XMLName xml.Name           `xml:"OrderHistoryWrapper"`
+	History []OrderHistoryItem `xml:"OrderHistory"`
+}
+
+// OrderNote is a note of the order.
+type OrderNote struct {
+	XMLName          xml.Name `xml:"Note"`
+	ID               string   `xml:"Id,omitempty"`
+	UserID           string   `xml:"UserId"`
+	Description      string
+	LastDateModifier *time.Time `xml:",omitempty"`
+}
+
+// OrderNotesResponse is the response of GetOrderNotes.
+type OrderNotesResponse struct {
+	XMLName xml.Name    `xml:"Notes"`
+	Notes   []OrderNote `xml:"Note"`
+}
+
+// NumberPortabilityRequest is the request of CheckPortability.
+type NumberPortabilityRequest struct {
+	XMLName xml.Name `xml:"NumberPortabilityRequest"`
+	TnList  []string `xml:"TnList>Tn"`
+}
+
+// NumberPortabilityResponse tells which numbers can be ported in and how.
+type NumberPortabilityResponse struct {
+	PortableNumbers []string `xml:"PortableNumbers>Tn"`
+	// PortType is AUTOMATED, MANUAL_OFFNET, MANUAL_ONNET or INTERNAL.
+	PortType                    string
+	SupportedRateCenters        []RateCenterGroup `xml:"SupportedRateCenters>RateCenterGroup"`
+	UnsupportedRateCenters      []RateCenterGroup `xml:"UnsupportedRateCenters>RateCenterGroup"`
+	PartnerSupportedRateCenters []RateCenterGroup `xml:"PartnerSupportedRateCenters>RateCenterGroup"`
+	SupportedLosingCarriers     []LosingCarrier   `xml:"SupportedLosingCarriers>LosingCarrierTnList"`
+	UnsupportedLosingCarriers   []LosingCarrier   `xml:"UnsupportedLosingCarriers>LosingCarrierTnList"`
+}
+
+// RateCenterGroup are the numbers of the rate center.
+type RateCenterGroup struct {
+	RateCenter string
+	City       string
+	State      string
+	LATA       string
+	Tiers      []string `xml:"Tiers>Tier"`
+	TnList     []string `xml:"TnList>Tn"`
+}
+
+// LosingCarrier is the carrier the numbers are ported from.
+type LosingCarrier struct {
+	SPID                   string   `xml:"LosingCarrierSPID"`
+	Name                   string   `xml:"LosingCarrierName"`
+	IsWireless             bool     `xml:"LosingCarrierIsWireless"`
+	AccountNumberRequired  bool     `xml:"LosingCarrierAccountNumberRequired"`
+	MinimumPortingInterval string   `xml:"LosingCarrierMinimumPortingInterval"`
+	TnList                 []string `xml:"TnList>Tn"`
+}
+
+// Subscriber is the owner of the ported numbers (as known by the losing carrier).
+type Subscriber struct {
+	// SubscriberType is BUSINESS or RESIDENTIAL.
+	SubscriberType string
+	BusinessName   string `xml:",omitempty"`
+	FirstName      string `xml:",omitempty"`
+	LastName       string `xml:",omitempty"`
+	ServiceAddress Address
+}
+
+// WirelessInfo is the account of the numbers ported from a wireless carrier.
+type WirelessInfo struct {
+	AccountNumber string
+	PinNumber     string `xml:",omitempty"`
+}
+
+// Processing statuses of the port-in orders.
+const (
+	PortInStatusPendingDocuments = "PENDING_DOCUMENTS"
+	PortInStatusSubmitted        = "SUBMITTED"
+	PortInStatusFOC              = "FOC"
+	PortInStatusRequestedSupp    = "REQUESTED_SUPP"
+	PortInStatusComplete         = "COMPLETE"
+	PortInStatusCancelled        = "CANCELLED"
+	PortInStatusRequestedCancel  = "REQUESTED_CANCEL"
+	PortInStatusException        = "EXCEPTION"
+)
+
+// PortIn is the port-in (LNP) order of the numbers.
+type PortIn struct {
+	XMLName                xml.Name `xml:"LnpOrder"`
+	CustomerOrderID        string   `xml:"CustomerOrderId,omitempty"`
+	BillingTelephoneNumber string
+	Subscriber             Subscriber
+	LoaAuthorizingPerson   string
+	WirelessInfo           *WirelessInfo `xml:",omitempty"`
+	ListOfPhoneNumbers     []string      `xml:"ListOfPhoneNumbers>PhoneNumber"`
+	SiteID                 string        `xml:"SiteId"`
+	PeerID                 string        `xml:"PeerId,omitempty"`
+	// RequestedFocDate is the requested date of the port (the earliest possible date if nil).
+	RequestedFocDate *time.Time `xml:",omitempty"`
+	PartialPort      bool       `xml:",omitempty"`
+	Triggered        bool
+}
+
+// PortInUpdate (supplemental order) changes the pending port-in order. Empty fields are not changed.
+type PortInUpdate struct {
+	XMLName                xml.Name      `xml:"LnpOrderSupp"`
+	CustomerOrderID        string        `xml:"CustomerOrderId,omitempty"`
+	BillingTelephoneNumber string        `xml:",omitempty"`
+	Subscriber             *Subscriber   `xml:",omitempty"`
+	LoaAuthorizingPerson   string        `xml:",omitempty"`
+	WirelessInfo           *WirelessInfo `xml:",omitempty"`
+	SiteID                 string        `xml:"SiteId,omitempty"`
+	PeerID                 string        `xml:"PeerId,omitempty"`
+	RequestedFocDate       *time.Time    `xml:",omitempty"`
+}
+
+// PortInResponse is the state of the port-in order.
+type PortInResponse struct {
+	XMLName                xml.Name `xml:"LnpOrderResponse"`
+	OrderID                string   `xml:"OrderId"`
+	Status                 ErrorDetail
+	ProcessingStatus       string
+	CustomerOrderID        string `xml:"CustomerOrderId"`
+	BillingTelephoneNumber string
+	Subscriber             Subscriber
+	LoaAuthorizingPerson   string
+	ListOfPhoneNumbers     []string `xml:"ListOfPhoneNumbers>PhoneNumber"`
+	SiteID                 string   `xml:"SiteId"`
+	PeerID                 string   `xml:"PeerId"`
+	PartialPort            bool
+	Triggered              bool
+	BillingType            string
+	// RequestedFocDate is the requested date of the port.
+	RequestedFocDate *time.Time
+	// ActualFocDate is the date of the port confirmed by the losing carrier (FOC).
+	ActualFocDate    *time.Time
+	OrderCreateDate  time.Time
+	LastModifiedDate time.Time
+	UserID           string        `xml:"userId"`
+	Errors           []ErrorDetail `xml:"Errors"`
+}
+
+// fileUploadResponse is the response of the document upload.
+type fileUploadResponse struct {
+	FileName      string `xml:"filename"`
+	ResultCode    int    `xml:"resultCode"`
+	ResultMessage string `xml:"resultMessage"`
+}
+
+// fileListResponse is the list of the uploaded documents.
+type fileListResponse struct {
+	FileCount     int      `xml:"fileCount"`
+	FileNames     []string `xml:"fileNames"`
+	ResultCode    int      `xml:"resultCode"`
+	ResultMessage string   `xml:"resultMessage"`
 }
 
 type CompletedNumbers struct {
 	TelephoneNumbers []TelephoneNumber `xml:"TelephoneNumber"`
 }
 
+type FailedNumbers struct {
+	FullNumber []string
+}
+
 type AreaCodeSearchAndOrderType struct {
 	AreaCode string
 	Quantity int
@@ -165,9 +882,51 @@ type TollFreeOrderRequest struct {
 type TelephoneNumberList struct {
 	TelephoneNumber []string
 }
+
+// moveNumbersRequest is the list of the numbers to move to the sip-peer.
+type moveNumbersRequest struct {
+	XMLName    xml.Name `xml:"SipPeerTelephoneNumbers"`
+	FullNumber []string
+}
+
+// moveNumbersResponse contains the errors of the numbers which were not moved.
+type moveNumbersResponse struct {
+	ErrorList []ErrorDetail `xml:"ErrorList>Error"`
+}
+
+// NumberError is the failure of a single number.
+type NumberError struct {
+	TelephoneNumber string
+	Err             error
+}
+
+// MoveNumbersResult reports which numbers were moved by MoveNumbers.
+type MoveNumbersResult struct {
+	Moved  TelephoneNumberList
+	Failed []NumberError
+}
 type SearchResult struct {
 	ResultCount         int
 	TelephoneNumberList TelephoneNumberList
+	// TelephoneNumberDetailList is returned instead of TelephoneNumberList when the details are requested.
+	TelephoneNumberDetailList TelephoneNumberDetailList
+}
+
+// TelephoneNumberDetail describes the number.
+type TelephoneNumberDetail struct {
+	FullNumber string
+	City       string
+	RateCenter string
+	State      string
+	LATA       string
+	Tier       string
+	VendorID   string `xml:"VendorId"`
+	VendorName string
+}
+
+// TelephoneNumberDetailList is a list of numbers with details.
+type TelephoneNumberDetailList struct {
+	TelephoneNumberDetail []TelephoneNumberDetail
 }
 type DisconnectTelephoneNumberOrder struct {
 	Name                               string `xml:"name,omitempty"`
@@ -179,6 +938,8 @@ type DisconnectTelephoneNumberOrderType struct {
 type DisconnectTelephoneNumberOrderResponse struct {
 	OrderRequest DisconnectOrderRequest
 	OrderStatus  string
+	// ErrorList contains the numbers which could not be disconnected.
+	ErrorList []ErrorDetail `xml:"ErrorList>Error"`
 }
 
 type DisconnectOrderRequest struct {
@@ -21,6 +21,7 @@ import (
 var (
 	defaultAccountsEndpoint  = "https://dashboard.bandwidth.com"
 	accountsPath             = "/api/accounts/"
+	tnsPath                  = "/api/tns/"
 	defaultMessagingEndpoint = "https://messaging.bandwidth.com"
 	messagingPath            = "/api/v2/users/"
 )
@@ -41,6 +42,89 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("RateLimitError: reset at %v", e.Reset)
 }
 
+// APIError is returned when the API responds with an error status (other than 429).
+// Use errors.As to get it from the error returned by the client methods.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the Bandwidth error code (ErrorCode / Code in XML, type / code in JSON).
+	Code string
+	// Description is the human readable description of the error.
+	Description string
+	// FieldErrors lists the request fields rejected by the messaging API.
+	FieldErrors []FieldError
+	// Details contains all entries of the Dashboard <ErrorList>, if any.
+	Details []ErrorDetail
+	// RequestID is the value of the X-Request-Id response header.
+	RequestID string
+	// Body is the raw response body.
+	Body []byte
+
+	retryAfter time.Duration
+}
+
+// FieldError describes a single invalid field of a messaging request.
+type FieldError struct {
+	FieldName   string `json:"fieldName"`
+	Description string `json:"description"`
+}
+
+// ErrorDetail is a single error reported by the Dashboard API.
+type ErrorDetail struct {
+	Code            string
+	Description     string
+	TelephoneNumber string `xml:",omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Description != "" {
+		return e.Description
+	}
+	if e.Code != "" {
+		return e.Code
+	}
+	return fmt.Sprintf("Http code %d", e.StatusCode)
+}
+
+// jsonErrorBody is the error body returned by the messaging API.
+type jsonErrorBody struct {
+	Type        string       `json:"type"`
+	Code        interface{}  `json:"code"`
+	Description string       `json:"description"`
+	Message     string       `json:"message"`
+	FieldErrors []FieldError `json:"fieldErrors"`
+}
+
+// xmlErrorBody matches the error envelopes returned by the Dashboard API.
+// The root element differs between endpoints so it is not checked.
+type xmlErrorBody struct {
+	ResponseStatus *struct {
+		ErrorCode   string
+		Description string
+	}
+	ErrorList []ErrorDetail `xml:"ErrorList>Error"`
+	Error     *ErrorDetail
+}
+
+func newAPIError(response *http.Response, body []byte) *APIError {
+	return &APIError{
+		StatusCode: response.StatusCode,
+		RequestID:  response.Header.Get("X-Request-Id"),
+		Body:       body,
+		retryAfter: parseRetryAfter(response.Header),
+	}
+}
+
+func newRateLimitError(response *http.Response) *RateLimitError {
+	if response.Header.Get("X-RateLimit-Reset") == "" {
+		if retryAfter := parseRetryAfter(response.Header); retryAfter > 0 {
+			return &RateLimitError{Reset: time.Now().Add(retryAfter)}
+		}
+	}
+	reset, _ := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64)
+	return &RateLimitError{Reset: time.Unix(int64((reset/1000)+1), 0)}
+}
+
 // Opts are the options to create the client.
 type Opts struct {
 	// mandatory options.
@@ -49,14 +133,22 @@ type Opts struct {
 	AccountsEndpoint, MessagingEndpoint string
 	HTTPClient                          *http.Client
 	Verbose                             bool
+	// RetryPolicy enables automatic retries of failed requests.
+	RetryPolicy *RetryPolicy
+	// MessagingRateLimit and AccountsRateLimit limit the rate of requests to the messaging and
+	// the dashboard (accounts) APIs. They are shared by all goroutines using the client.
+	MessagingRateLimit, AccountsRateLimit *RateLimit
 }
 
 // Client is main API object
 type Client struct {
 	accountID, apiToken, apiSecret, userName, password string
 	AccountsEndpoint, MessagingEndpoint                string
+	tnsEndpoint                                        string
 	httpClient                                         *http.Client
 	verbose                                            bool
+	retryPolicy                                        *RetryPolicy
+	limiters                                           map[endpointRequest]*tokenBucket
 }
 
 // New creates new instances of api
@@ -86,7 +178,11 @@ func New(opts Opts) (*Client, error) {
 		userName: opts.UserName, password: opts.Password,
 		AccountsEndpoint:  accounts + accountsPath + opts.AccountID,
 		MessagingEndpoint: messaging + messagingPath + opts.AccountID + "/messages", httpClient: client,
-		verbose: opts.Verbose}
+		tnsEndpoint: accounts + tnsPath, verbose: opts.Verbose, retryPolicy: opts.RetryPolicy,
+		limiters: map[endpointRequest]*tokenBucket{
+			messagingRequest: newTokenBucket(opts.MessagingRateLimit),
+			accountsRequest:  newTokenBucket(opts.AccountsRateLimit),
+		}}
 	return c, nil
 }
 
@@ -127,24 +223,24 @@ func (c *Client) checkJSONResponse(response *http.Response, responseBody interfa
 		return body, response.Header, nil
 	}
 	if response.StatusCode == 429 {
-		reset, _ := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64)
-		return nil, nil, &RateLimitError{Reset: time.Unix(int64((reset/1000)+1), 0)}
+		return nil, nil, newRateLimitError(response)
 	}
-	errorBody := make(map[string]interface{})
+	apiError := newAPIError(response, rawJSON)
 	if len(rawJSON) > 0 {
-		err = json.Unmarshal([]byte(rawJSON), &errorBody)
-		if err != nil {
-			return nil, nil, err
+		var errorBody jsonErrorBody
+		if json.Unmarshal(rawJSON, &errorBody) == nil {
+			apiError.Code = errorBody.Type
+			if errorBody.Code != nil {
+				apiError.Code = fmt.Sprintf("%v", errorBody.Code)
+			}
+			apiError.Description = errorBody.Description
+			if apiError.Description == "" {
+				apiError.Description = errorBody.Message
+			}
+			apiError.FieldErrors = errorBody.FieldErrors
 		}
 	}
-	message := errorBody["message"]
-	if message == nil {
-		message = errorBody["code"]
-	}
-	if message == nil {
-		return nil, nil, fmt.Errorf("Http code %d", response.StatusCode)
-	}
-	return nil, nil, errors.New(message.(string))
+	return nil, nil, apiError
 }
 
 func (c *Client) checkXMLResponse(response *http.Response, responseBody interface{}) (interface{}, http.Header, error) {
@@ -158,6 +254,11 @@ func (c *Client) checkXMLResponse(response *http.Response, responseBody interfac
 		return nil, nil, err
 	}
 	if response.StatusCode >= 200 && response.StatusCode < 400 {
+		if raw, ok := responseBody.(*[]byte); ok {
+			// downloaded document
+			*raw = rawXML
+			return raw, response.Header, nil
+		}
 		if len(rawXML) > 0 {
 			err = xml.Unmarshal([]byte(rawXML), &body)
 			if err != nil {
@@ -167,73 +268,124 @@ func (c *Client) checkXMLResponse(response *http.Response, responseBody interfac
 		return body, response.Header, nil
 	}
 	if response.StatusCode == 429 {
-		reset, _ := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64)
-		return nil, nil, &RateLimitError{Reset: time.Unix(int64((reset/1000)+1), 0)}
+		return nil, nil, newRateLimitError(response)
 	}
-
-	// TODO(bashar): Figure out how to deal with errors and what format they come in.
-	return nil, nil, fmt.Errorf("Http code %d", response.StatusCode)
+	apiError := newAPIError(response, rawXML)
+	if len(rawXML) > 0 {
+		var errorBody xmlErrorBody
+		if xml.Unmarshal(rawXML, &errorBody) == nil {
+			switch {
+			case errorBody.ResponseStatus != nil:
+				apiError.Code = errorBody.ResponseStatus.ErrorCode
+				apiError.Description = errorBody.ResponseStatus.Description
+			case len(errorBody.ErrorList) > 0:
+				apiError.Code = errorBody.ErrorList[0].Code
+				apiError.Description = errorBody.ErrorList[0].Description
+			case errorBody.Error != nil:
+				apiError.Code = errorBody.Error.Code
+				apiError.Description = errorBody.Error.Description
+			}
+			apiError.Details = errorBody.ErrorList
+		}
+	}
+	return nil, nil, apiError
 }
 
 func (c *Client) makeRequestInternal(ctx context.Context, method, path string, requestType endpointRequest, data ...interface{}) (interface{}, http.Header, error) {
-	request, err := c.createRequest(ctx, method, path, requestType)
 	var responseBody interface{}
-	if err != nil {
-		return nil, nil, err
-	}
 	if len(data) > 0 {
 		responseBody = data[0]
 	}
+	var query url.Values
+	var body []byte
+	var contentType string
 	if len(data) > 1 {
 		if method == "GET" {
-			var item map[string]string
-			if data[1] == nil {
-				item = make(map[string]string)
-			} else {
-				var ok bool
-				item, ok = data[1].(map[string]string)
-				if !ok {
-					item = make(map[string]string)
-					structType := reflect.TypeOf(data[1]).Elem()
-					structValue := reflect.ValueOf(data[1])
-					if !structValue.IsNil() {
-						structValue = structValue.Elem()
-						fieldCount := structType.NumField()
-						for i := 0; i < fieldCount; i++ {
-							fieldName := structType.Field(i).Name
-							fieldValue := structValue.Field(i).Interface()
-							if fieldValue == reflect.Zero(structType.Field(i).Type).Interface() {
-								//ignore fields with default values
-								continue
-							}
-							item[strings.Replace(strings.ToLower(string(fieldName[0]))+fieldName[1:], "ID", "Id", -1)] = fmt.Sprintf("%v", fieldValue)
-						}
-					}
-				}
-			}
-			query := make(url.Values)
-			for key, value := range item {
-				query[key] = []string{value}
-			}
-			request.URL.RawQuery = query.Encode()
+			query = buildQuery(data[1])
+		} else if raw, ok := data[1].(*rawContent); ok {
+			body, contentType = raw.data, raw.contentType
 		} else {
-			var body []byte
 			var err error
 			switch requestType {
 			case messagingRequest:
-				request.Header.Set("Content-Type", "application/json")
 				body, err = json.Marshal(data[1])
+				contentType = "application/json"
 			default:
-				request.Header.Set("Content-Type", "application/xml")
 				body, err = xml.Marshal(data[1])
+				contentType = "application/xml"
 			}
 			if err != nil {
 				return nil, nil, err
 			}
-			request.Body = nopCloser{bytes.NewReader(body)}
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		result, header, err := c.doRequest(ctx, method, path, requestType, query, body, contentType, responseBody)
+		if err == nil || ctx.Err() != nil || !c.retryPolicy.canRetry(ctx, method, attempt, err) {
+			return result, header, err
+		}
+		if err := sleep(ctx, c.retryPolicy.backoff(attempt, err)); err != nil {
+			return nil, nil, err
+		}
+	}
+}
 
+// rawContent is a request body sent as is (like an uploaded document).
+type rawContent struct {
+	contentType string
+	data        []byte
+}
+
+func buildQuery(params interface{}) url.Values {
+	var item map[string]string
+	if params == nil {
+		item = make(map[string]string)
+	} else {
+		var ok bool
+		item, ok = params.(map[string]string)
+		if !ok {
+			item = make(map[string]string)
+			structType := reflect.TypeOf(params).Elem()
+			structValue := reflect.ValueOf(params)
+			if !structValue.IsNil() {
+				structValue = structValue.Elem()
+				fieldCount := structType.NumField()
+				for i := 0; i < fieldCount; i++ {
+					fieldName := structType.Field(i).Name
+					fieldValue := structValue.Field(i).Interface()
+					if fieldValue == reflect.Zero(structType.Field(i).Type).Interface() {
+						//ignore fields with default values
+						continue
+					}
+					item[strings.Replace(strings.ToLower(string(fieldName[0]))+fieldName[1:], "ID", "Id", -1)] = fmt.Sprintf("%v", fieldValue)
+				}
+			}
 		}
 	}
+	query := make(url.Values)
+	for key, value := range item {
+		query[key] = []string{value}
+	}
+	return query
+}
+
+// doRequest makes a single attempt of the request. The body is read from a new reader every time
+// so the request can be replayed safely.
+func (c *Client) doRequest(ctx context.Context, method, path string, requestType endpointRequest, query url.Values, body []byte, contentType string, responseBody interface{}) (interface{}, http.Header, error) {
+	request, err := c.createRequest(ctx, method, path, requestType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.limiters[requestType].Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	if query != nil {
+		request.URL.RawQuery = query.Encode()
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", contentType)
+		request.Body = nopCloser{bytes.NewReader(body)}
+	}
 	if c.verbose {
 		dump, err := httputil.DumpRequestOut(request, true)
 		if err != nil {
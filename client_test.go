@@ -2,6 +2,7 @@ package bandwidth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/textproto"
@@ -153,3 +154,68 @@ func TestMakeRequestWithEmptyResponse(t *testing.T) {
 	result, _, _ := api.makeMessagingRequest(context.Background(), http.MethodGet, api.MessagingEndpoint, &[]interface{}{})
 	expect(t, len(*result.(*[]interface{})), 0)
 }
+
+func TestCheckJSONResponseAPIError(t *testing.T) {
+	api := getAPI("https://localhost")
+	resp := createFakeResponse(`{
+		"type": "request-validation",
+		"description": "Your request could not be accepted",
+		"fieldErrors": [{"fieldName": "from", "description": "'+invalid' must be replaced with a valid E164 formatted telephone number"}]
+	}`, 400)
+	resp.Header = http.Header{"X-Request-Id": []string{"req-1"}}
+	_, _, err := api.checkJSONResponse(resp, nil)
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Expected *APIError - Got %T", err)
+	}
+	expect(t, apiError.StatusCode, 400)
+	expect(t, apiError.Code, "request-validation")
+	expect(t, apiError.Description, "Your request could not be accepted")
+	expect(t, apiError.RequestID, "req-1")
+	expect(t, len(apiError.FieldErrors), 1)
+	expect(t, apiError.FieldErrors[0].FieldName, "from")
+	expect(t, err.Error(), "Your request could not be accepted")
+}
+
+func TestCheckXMLResponseAPIError(t *testing.T) {
+	api := getAPI("https://localhost")
+	_, _, err := api.checkXMLResponse(createFakeResponse(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SearchResult>
+			<ResponseStatus>
+				<ErrorCode>4000</ErrorCode>
+				<Description>The area code of number 999 is not valid.</Description>
+			</ResponseStatus>
+		</SearchResult>`, 400), nil)
+	apiError := err.(*APIError)
+	expect(t, apiError.StatusCode, 400)
+	expect(t, apiError.Code, "4000")
+	expect(t, apiError.Description, "The area code of number 999 is not valid.")
+
+	_, _, err = api.checkXMLResponse(createFakeResponse(`
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<OrderResponse>
+			<ErrorList>
+				<Error>
+					<Code>5005</Code>
+					<Description>The telephone number is unavailable for ordering</Description>
+					<TelephoneNumber>7341231234</TelephoneNumber>
+				</Error>
+				<Error>
+					<Code>5005</Code>
+					<Description>The telephone number is unavailable for ordering</Description>
+					<TelephoneNumber>7341232222</TelephoneNumber>
+				</Error>
+			</ErrorList>
+		</OrderResponse>`, 409), nil)
+	apiError = err.(*APIError)
+	expect(t, apiError.StatusCode, 409)
+	expect(t, apiError.Code, "5005")
+	expect(t, len(apiError.Details), 2)
+	expect(t, apiError.Details[1].TelephoneNumber, "7341232222")
+
+	_, _, err = api.checkXMLResponse(createFakeResponse("", 401), nil)
+	apiError = err.(*APIError)
+	expect(t, apiError.StatusCode, 401)
+	expect(t, err.Error(), "Http code 401")
+}
@@ -0,0 +1,133 @@
+package bandwidth
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+var (
+	stateCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	zipPattern       = regexp.MustCompile(`^\d{5}$`)
+	plusFourPattern  = regexp.MustCompile(`^\d{4}$`)
+)
+
+// AddressValidationError is returned when the address can't be used for E911.
+type AddressValidationError struct {
+	// Field is the missing or malformed field (found before calling the API).
+	Field string
+	// Code and Description are the reason reported by the API.
+	Code        string
+	Description string
+	// SuggestedAddress is the address found by the API when it differs from the requested one.
+	SuggestedAddress *Address
+	// Err is the error of the API.
+	Err error
+}
+
+func (e *AddressValidationError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("invalid E911 address: %s %s", e.Field, e.Description)
+	}
+	return fmt.Sprintf("invalid E911 address: %s", e.Description)
+}
+
+// Unwrap returns the error of the API.
+func (e *AddressValidationError) Unwrap() error {
+	return e.Err
+}
+
+// checkE911Address checks that the address has all fields required for E911.
+func checkE911Address(address *Address) error {
+	for _, field := range []struct {
+		name, value string
+	}{
+		{"HouseNumber", address.HouseNumber},
+		{"StreetName", address.StreetName},
+		{"City", address.City},
+		{"StateCode", address.StateCode},
+		{"Zip", address.Zip},
+	} {
+		if field.value == "" {
+			return &AddressValidationError{Field: field.name, Description: "is required"}
+		}
+	}
+	if !stateCodePattern.MatchString(address.StateCode) {
+		return &AddressValidationError{Field: "StateCode", Description: "must be 2 upper-case letters"}
+	}
+	if !zipPattern.MatchString(address.Zip) {
+		return &AddressValidationError{Field: "Zip", Description: "must be 5 digits"}
+	}
+	if address.PlusFour != "" && !plusFourPattern.MatchString(address.PlusFour) {
+		return &AddressValidationError{Field: "PlusFour", Description: "must be 4 digits"}
+	}
+	return nil
+}
+
+// ValidateE911Address validates the address and returns it as it is known by the emergency services.
+// Invalid addresses (400 and 409 responses) are reported by *AddressValidationError,
+// other failures (like authentication) by *APIError.
+func (c *Client) ValidateE911Address(ctx context.Context, address *Address) (*Address, error) {
+	if err := checkE911Address(address); err != nil {
+		return nil, err
+	}
+	path := c.AccountsEndpoint + "/geocodeRequest"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &geocodeResponse{}, &geocodeRequest{Address: *address})
+	if err != nil {
+		var apiError *APIError
+		if !errors.As(err, &apiError) || (apiError.StatusCode != http.StatusBadRequest && apiError.StatusCode != http.StatusConflict) {
+			return nil, err
+		}
+		validationError := &AddressValidationError{Code: apiError.Code, Description: apiError.Error(), Err: err}
+		// the conflicting address comes in the error response
+		var response geocodeResponse
+		if xml.Unmarshal(apiError.Body, &response) == nil {
+			validationError.SuggestedAddress = response.GeocodedAddress
+		}
+		return nil, validationError
+	}
+	geocoded := result.(*geocodeResponse).GeocodedAddress
+	if geocoded == nil {
+		return address, nil
+	}
+	return geocoded, nil
+}
+
+// CreateE911Order submits the order binding the address to the numbers and/or endpoints.
+func (c *Client) CreateE911Order(ctx context.Context, order *E911Order) (*E911OrderDetails, error) {
+	if order.E911ServiceAction != E911Delete {
+		if err := checkE911Address(&order.Address); err != nil {
+			return nil, err
+		}
+	}
+	path := c.AccountsEndpoint + "/e911s"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &E911OrderResponse{}, order)
+	if err != nil {
+		return nil, err
+	}
+	return &result.(*E911OrderResponse).E911Order, nil
+}
+
+// GetE911Order returns the state of the E911 order.
+func (c *Client) GetE911Order(ctx context.Context, orderID string) (*E911OrderDetails, error) {
+	path := c.AccountsEndpoint + "/e911s/" + orderID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &E911OrderDetails{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*E911OrderDetails), nil
+}
+
+// E911OrderPages returns the iterator over the pages (*OrdersResponse) of the E911 orders.
+func (c *Client) E911OrderPages(filter *OrdersFilter) *PageIterator {
+	return c.orderPages(c.AccountsEndpoint+"/e911s", filter, func() pagedResponse { return &OrdersResponse{} })
+}
+
+// ListE911Orders returns the iterator over the E911 orders.
+// The pages are fetched when needed.
+func (c *Client) ListE911Orders(filter *OrdersFilter) *OrderIterator {
+	return &OrderIterator{pages: c.E911OrderPages(filter)}
+}
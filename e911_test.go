@@ -0,0 +1,232 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func testE911Address() Address {
+	return Address{HouseNumber: "900", StreetName: "Main Campus", StreetSuffix: "Dr", City: "Raleigh", StateCode: "NC", Zip: "27606"}
+}
+
+func TestValidateE911Address(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/geocodeRequest", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<RequestAddress><HouseNumber>900</HouseNumber><StreetName>Main Campus</StreetName><StreetSuffix>Dr</StreetSuffix><City>Raleigh</City><StateCode>NC</StateCode><Zip>27606</Zip></RequestAddress>",
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<GeocodeRequestResponse>
+			<GeocodedAddress>
+				<HouseNumber>900</HouseNumber>
+				<StreetName>MAIN CAMPUS</StreetName>
+				<StreetSuffix>DR</StreetSuffix>
+				<City>RALEIGH</City>
+				<StateCode>NC</StateCode>
+				<Zip>27606</Zip>
+				<PlusFour>5214</PlusFour>
+				<Country>US</Country>
+			</GeocodedAddress>
+		</GeocodeRequestResponse>`,
+	}})
+	defer server.Close()
+	address := testE911Address()
+	result, err := api.ValidateE911Address(context.Background(), &address)
+	if err != nil {
+		t.Errorf("Failed call of ValidateE911Address(): %v", err)
+		return
+	}
+	expect(t, result.StreetName, "MAIN CAMPUS")
+	expect(t, result.PlusFour, "5214")
+}
+
+func TestValidateE911AddressWithSuggestion(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/geocodeRequest", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusConflict,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<GeocodeRequestResponse>
+			<GeocodedAddress>
+				<HouseNumber>900</HouseNumber>
+				<StreetName>MAIN CAMPUS</StreetName>
+				<StreetSuffix>DR</StreetSuffix>
+				<City>RALEIGH</City>
+				<StateCode>NC</StateCode>
+				<Zip>27606</Zip>
+			</GeocodedAddress>
+			<ResponseStatus>
+				<ErrorCode>12076</ErrorCode>
+				<Description>The address has been modified, please review the suggested address</Description>
+			</ResponseStatus>
+		</GeocodeRequestResponse>`,
+	}})
+	defer server.Close()
+	address := testE911Address()
+	_, err := api.ValidateE911Address(context.Background(), &address)
+	var validationError *AddressValidationError
+	if !errors.As(err, &validationError) {
+		t.Fatalf("Expected AddressValidationError - Got %v", err)
+	}
+	expect(t, validationError.Code, "12076")
+	expect(t, validationError.SuggestedAddress.StreetName, "MAIN CAMPUS")
+	var apiError *APIError
+	expect(t, errors.As(err, &apiError), true)
+	expect(t, apiError.StatusCode, http.StatusConflict)
+}
+
+func TestValidateE911AddressUnauthorized(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/geocodeRequest", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusUnauthorized,
+	}})
+	defer server.Close()
+	address := testE911Address()
+	_, err := api.ValidateE911Address(context.Background(), &address)
+	if _, ok := err.(*AddressValidationError); ok {
+		t.Fatalf("Unexpected AddressValidationError - Got %v", err)
+	}
+	apiError, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("Expected APIError - Got %v", err)
+	}
+	expect(t, apiError.StatusCode, http.StatusUnauthorized)
+}
+
+func TestValidateE911AddressWithMissingField(t *testing.T) {
+	api := getAPI("http://localhost")
+	address := testE911Address()
+	address.Zip = "2760"
+	_, err := api.ValidateE911Address(context.Background(), &address)
+	validationError, ok := err.(*AddressValidationError)
+	if !ok {
+		t.Fatalf("Expected AddressValidationError - Got %v", err)
+	}
+	expect(t, validationError.Field, "Zip")
+	address = testE911Address()
+	address.City = ""
+	_, err = api.ValidateE911Address(context.Background(), &address)
+	expect(t, err.(*AddressValidationError).Field, "City")
+}
+
+func TestCreateE911Order(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/e911s", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<E911Order><CustomerOrderId>abc</CustomerOrderId><Address><HouseNumber>900</HouseNumber><StreetName>Main Campus</StreetName><StreetSuffix>Dr</StreetSuffix><City>Raleigh</City><StateCode>NC</StateCode><Zip>27606</Zip></Address><CallerName>Company</CallerName><TelephoneNumbers><TelephoneNumber>9195551212</TelephoneNumber></TelephoneNumbers><AlternateEndUserIdentifiers><AlternateEndUserIdentifier><Identifier>softphone-1</Identifier><CallbackNumber>9195551212</CallbackNumber></AlternateEndUserIdentifier></AlternateEndUserIdentifiers><E911ServiceAction>Upsert</E911ServiceAction></E911Order>",
+		StatusCodeToSend: http.StatusCreated,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<E911OrderResponse>
+			<E911Order>
+				<CustomerOrderId>abc</CustomerOrderId>
+				<OrderId>1-2-3-4</OrderId>
+				<OrderCreateDate>2019-11-05T13:48:43.238Z</OrderCreateDate>
+				<ProcessingStatus>RECEIVED</ProcessingStatus>
+				<CallerName>Company</CallerName>
+				<TelephoneNumbers>
+					<TelephoneNumber>9195551212</TelephoneNumber>
+				</TelephoneNumbers>
+				<AlternateEndUserIdentifiers>
+					<AlternateEndUserIdentifier>
+						<Identifier>softphone-1</Identifier>
+						<CallbackNumber>9195551212</CallbackNumber>
+					</AlternateEndUserIdentifier>
+				</AlternateEndUserIdentifiers>
+				<E911ServiceAction>Upsert</E911ServiceAction>
+			</E911Order>
+		</E911OrderResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.CreateE911Order(context.Background(), &E911Order{
+		CustomerOrderID:  "abc",
+		Address:          testE911Address(),
+		CallerName:       "Company",
+		TelephoneNumbers: &TelephoneNumberList{TelephoneNumber: []string{"9195551212"}},
+		AlternateEndUserIdentifiers: &AlternateEndUserIdentifiers{AlternateEndUserIdentifier: []AlternateEndUserIdentifier{
+			AlternateEndUserIdentifier{Identifier: "softphone-1", CallbackNumber: "9195551212"},
+		}},
+		E911ServiceAction: E911Upsert,
+	})
+	if err != nil {
+		t.Errorf("Failed call of CreateE911Order(): %v", err)
+		return
+	}
+	expect(t, result.OrderID, "1-2-3-4")
+	expect(t, result.ProcessingStatus, OrderStatusReceived)
+	expect(t, result.AlternateEndUserIdentifiers[0].Identifier, "softphone-1")
+}
+
+func TestCreateE911OrderWithInvalidAddress(t *testing.T) {
+	api := getAPI("http://localhost")
+	_, err := api.CreateE911Order(context.Background(), &E911Order{
+		Address:           Address{HouseNumber: "900", StreetName: "Main Campus", City: "Raleigh", StateCode: "nc", Zip: "27606"},
+		TelephoneNumbers:  &TelephoneNumberList{TelephoneNumber: []string{"9195551212"}},
+		E911ServiceAction: E911Upsert,
+	})
+	validationError, ok := err.(*AddressValidationError)
+	if !ok {
+		t.Fatalf("Expected AddressValidationError - Got %v", err)
+	}
+	expect(t, validationError.Field, "StateCode")
+}
+
+func TestGetE911Order(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/e911s/1-2-3-4", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<E911Order>
+			<OrderId>1-2-3-4</OrderId>
+			<ProcessingStatus>FAILED</ProcessingStatus>
+			<ErrorList>
+				<Error>
+					<Code>12055</Code>
+					<Description>Address could not be validated</Description>
+				</Error>
+			</ErrorList>
+		</E911Order>`,
+	}})
+	defer server.Close()
+	result, err := api.GetE911Order(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of GetE911Order(): %v", err)
+		return
+	}
+	expect(t, result.ProcessingStatus, OrderStatusFailed)
+	expect(t, result.ErrorList[0].Code, "12055")
+}
+
+func TestListE911Orders(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/e911s?page=1&size=300&tn=9195551212", accountsPath, testAccountID),
+		ContentToSend: `
+		<ResponseSelectWrapper>
+			<ListOrderIdUserIdDate>
+				<TotalCount>1</TotalCount>
+				<OrderIdUserIdDate>
+					<CountOfTNs>1</CountOfTNs>
+					<OrderType>e911</OrderType>
+					<orderId>1-2-3-4</orderId>
+					<OrderStatus>COMPLETE</OrderStatus>
+				</OrderIdUserIdDate>
+			</ListOrderIdUserIdDate>
+		</ResponseSelectWrapper>`,
+	}})
+	defer server.Close()
+	it := api.ListE911Orders(&OrdersFilter{TelephoneNumber: "9195551212"})
+	var orders []OrderSummary
+	for it.Next(context.Background()) {
+		orders = append(orders, it.Order())
+	}
+	expectNil(t, it.Err())
+	expect(t, len(orders), 1)
+	expect(t, orders[0].OrderID, "1-2-3-4")
+	expect(t, orders[0].OrderType, "e911")
+}
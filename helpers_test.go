@@ -38,7 +38,8 @@ func shouldFail(t *testing.T, action func() (interface{}, error)) error {
 }
 
 func getAPI(endpoint string) *Client {
-	api, _ := New(Opts{testAccountID, "apiToken", "apiSecret", "test", "password", endpoint, endpoint, nil, true})
+	api, _ := New(Opts{AccountID: testAccountID, APIToken: "apiToken", APISecret: "apiSecret", UserName: "test", Password: "password",
+		AccountsEndpoint: endpoint, MessagingEndpoint: endpoint, Verbose: true})
 	return api
 }
 
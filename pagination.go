@@ -0,0 +1,145 @@
+package bandwidth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Links are the pagination links of the list responses.
+// Every link looks like Link=<https://...?page=2&size=500>;rel="next";
+type Links struct {
+	First string `xml:"first"`
+	Next  string `xml:"next"`
+	Last  string `xml:"last"`
+}
+
+// pagedResponse is a response of the paginated list.
+type pagedResponse interface {
+	pageLinks() Links
+}
+
+// nextQuery returns the query of the next page or nil if this is the last page.
+func (l Links) nextQuery() map[string]string {
+	link := l.Next
+	start := strings.Index(link, "<")
+	end := strings.LastIndex(link, ">")
+	if start < 0 || end <= start {
+		return nil
+	}
+	nextURL, err := url.Parse(link[start+1 : end])
+	if err != nil {
+		return nil
+	}
+	query := make(map[string]string)
+	for key, values := range nextURL.Query() {
+		query[key] = values[0]
+	}
+	return query
+}
+
+// PageIterator fetches the pages of a list lazily following the "next" links.
+type PageIterator struct {
+	client  *Client
+	path    string
+	query   map[string]string
+	newPage func() pagedResponse
+	page    pagedResponse
+	last    bool
+	err     error
+}
+
+func (c *Client) newPageIterator(path string, query map[string]string, newPage func() pagedResponse) *PageIterator {
+	return &PageIterator{client: c, path: path, query: query, newPage: newPage}
+}
+
+// Next fetches the next page. It returns false when there are no more pages or on error.
+// The context is checked before every page.
+func (it *PageIterator) Next(ctx context.Context) bool {
+	if it.last || it.err != nil {
+		return false
+	}
+	if it.err = ctx.Err(); it.err != nil {
+		return false
+	}
+	result, _, err := it.client.makeAccountsRequest(ctx, http.MethodGet, it.path, it.newPage(), it.query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = result.(pagedResponse)
+	// the next link points to the public API host, so only its query is used
+	it.query = it.page.pageLinks().nextQuery()
+	it.last = it.query == nil
+	return true
+}
+
+// Page returns the current page (pointer to the response type of the list).
+func (it *PageIterator) Page() interface{} {
+	return it.page
+}
+
+// Err returns the error which stopped the iteration.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// NumberIterator iterates over the numbers of a paginated list.
+type NumberIterator struct {
+	pages   *PageIterator
+	numbers func(page interface{}) []string
+	buffer  []string
+	current string
+}
+
+// Next advances to the next number, fetching the next page if needed.
+func (it *NumberIterator) Next(ctx context.Context) bool {
+	for len(it.buffer) == 0 {
+		if !it.pages.Next(ctx) {
+			return false
+		}
+		it.buffer = it.numbers(it.pages.Page())
+	}
+	it.current, it.buffer = it.buffer[0], it.buffer[1:]
+	return true
+}
+
+// Number returns the current number.
+func (it *NumberIterator) Number() string {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration.
+func (it *NumberIterator) Err() error {
+	return it.pages.Err()
+}
+
+// OrderIterator iterates over the orders of a paginated list.
+type OrderIterator struct {
+	pages   *PageIterator
+	buffer  []OrderSummary
+	current OrderSummary
+}
+
+// Next advances to the next order, fetching the next page if needed.
+func (it *OrderIterator) Next(ctx context.Context) bool {
+	for len(it.buffer) == 0 {
+		if !it.pages.Next(ctx) {
+			return false
+		}
+		it.buffer = it.pages.Page().(*OrdersResponse).Orders
+	}
+	it.current, it.buffer = it.buffer[0], it.buffer[1:]
+	return true
+}
+
+// Order returns the current order.
+func (it *OrderIterator) Order() OrderSummary {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration.
+func (it *OrderIterator) Err() error {
+	return it.pages.Err()
+}
@@ -0,0 +1,123 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestLinksNextQuery(t *testing.T) {
+	links := Links{Next: `Link=<https://dashboard.bandwidth.com:443/v1.0/accounts/123/inserviceNumbers?page=2&size=500>;rel="next";`}
+	expect(t, links.nextQuery(), map[string]string{"page": "2", "size": "500"})
+	expect(t, Links{}.nextQuery(), map[string]string(nil))
+}
+
+func TestListInServiceNumbers(t *testing.T) {
+	path := fmt.Sprintf("%s%s/inserviceNumbers", accountsPath, testAccountID)
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery: path + "?areacode=734&page=1&size=2",
+			ContentToSend: `
+			<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+			<TNs>
+				<TotalCount>3</TotalCount>
+				<Links>
+					<first>Link=&lt;https://dashboard.bandwidth.com:443/v1.0/accounts/123/inserviceNumbers?areacode=734&amp;page=1&amp;size=2&gt;;rel="first";</first>
+					<next>Link=&lt;https://dashboard.bandwidth.com:443/v1.0/accounts/123/inserviceNumbers?areacode=734&amp;page=2&amp;size=2&gt;;rel="next";</next>
+				</Links>
+				<TelephoneNumbers>
+					<Count>2</Count>
+					<TelephoneNumber>7341231234</TelephoneNumber>
+					<TelephoneNumber>7341232222</TelephoneNumber>
+				</TelephoneNumbers>
+			</TNs>`,
+		},
+		RequestHandler{
+			PathAndQuery: path + "?areacode=734&page=2&size=2",
+			ContentToSend: `
+			<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+			<TNs>
+				<TotalCount>3</TotalCount>
+				<Links>
+					<first>Link=&lt;https://dashboard.bandwidth.com:443/v1.0/accounts/123/inserviceNumbers?areacode=734&amp;page=1&amp;size=2&gt;;rel="first";</first>
+				</Links>
+				<TelephoneNumbers>
+					<Count>1</Count>
+					<TelephoneNumber>7341233333</TelephoneNumber>
+				</TelephoneNumbers>
+			</TNs>`,
+		},
+	})
+	defer server.Close()
+	it := api.ListInServiceNumbers(&InServiceNumbersFilter{AreaCode: "734", PageSize: 2})
+	var numbers []string
+	for it.Next(context.Background()) {
+		numbers = append(numbers, it.Number())
+	}
+	expectNil(t, it.Err())
+	expect(t, numbers, []string{"7341231234", "7341232222", "7341233333"})
+}
+
+func TestListInServiceNumbersContextCancelled(t *testing.T) {
+	path := fmt.Sprintf("%s%s/inserviceNumbers", accountsPath, testAccountID)
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery: path + "?page=1&size=500",
+			ContentToSend: `
+			<TNs>
+				<Links>
+					<next>Link=&lt;https://dashboard.bandwidth.com:443/v1.0/accounts/123/inserviceNumbers?page=2&amp;size=500&gt;;rel="next";</next>
+				</Links>
+				<TelephoneNumbers>
+					<TelephoneNumber>7341231234</TelephoneNumber>
+				</TelephoneNumbers>
+			</TNs>`,
+		},
+	})
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	pages := api.InServiceNumberPages(nil)
+	if !pages.Next(ctx) {
+		t.Fatalf("Failed to fetch the first page: %v", pages.Err())
+	}
+	expect(t, pages.Page().(*InServiceNumbersResponse).TelephoneNumbers, []string{"7341231234"})
+	cancel()
+	expect(t, pages.Next(ctx), false)
+	expect(t, pages.Err(), context.Canceled)
+}
+
+func TestListPeerNumbers(t *testing.T) {
+	siteID := "12345"
+	peerID := "123123"
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/tns", accountsPath, testAccountID, siteID, peerID),
+			ContentToSend: `
+			<SipPeerTelephoneNumbersResponse>
+				<SipPeerTelephoneNumbers>
+					<SipPeerTelephoneNumber><FullNumber>7341231234</FullNumber></SipPeerTelephoneNumber>
+				</SipPeerTelephoneNumbers>
+				<Links>
+					<next>Link=&lt;https://dashboard.bandwidth.com:443/v1.0/accounts/123/sites/12345/sippeers/123123/tns?page=2&amp;size=1&gt;;rel="next";</next>
+				</Links>
+			</SipPeerTelephoneNumbersResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/tns?page=2&size=1", accountsPath, testAccountID, siteID, peerID),
+			ContentToSend: `
+			<SipPeerTelephoneNumbersResponse>
+				<SipPeerTelephoneNumbers>
+					<SipPeerTelephoneNumber><FullNumber>7341232222</FullNumber></SipPeerTelephoneNumber>
+				</SipPeerTelephoneNumbers>
+			</SipPeerTelephoneNumbersResponse>`,
+		},
+	})
+	defer server.Close()
+	it := api.ListPeerNumbers(siteID, peerID)
+	var numbers []string
+	for it.Next(context.Background()) {
+		numbers = append(numbers, it.Number())
+	}
+	expectNil(t, it.Err())
+	expect(t, numbers, []string{"7341231234", "7341232222"})
+}
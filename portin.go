@@ -0,0 +1,103 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CheckPortability checks if the numbers can be ported in.
+// fullCheck also returns the losing carriers of the numbers.
+func (c *Client) CheckPortability(ctx context.Context, numbers []string, fullCheck bool) (*NumberPortabilityResponse, error) {
+	path := c.AccountsEndpoint + "/lnpchecker"
+	if fullCheck {
+		path += "?fullCheck=true"
+	}
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &NumberPortabilityResponse{}, &NumberPortabilityRequest{TnList: numbers})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*NumberPortabilityResponse), nil
+}
+
+// CreatePortIn creates the port-in order. The order waits for the LOA (see UploadLOA) in PENDING_DOCUMENTS status.
+func (c *Client) CreatePortIn(ctx context.Context, portIn *PortIn) (*PortInResponse, error) {
+	path := c.AccountsEndpoint + "/portins"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &PortInResponse{}, portIn)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*PortInResponse), nil
+}
+
+// GetPortIn returns the port-in order including its status and FOC date.
+func (c *Client) GetPortIn(ctx context.Context, portInID string) (*PortInResponse, error) {
+	path := c.AccountsEndpoint + "/portins/" + portInID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &PortInResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*PortInResponse), nil
+}
+
+// UpdatePortIn submits the supplemental order (like a new requested FOC date) of the pending port-in.
+func (c *Client) UpdatePortIn(ctx context.Context, portInID string, update *PortInUpdate) (*PortInResponse, error) {
+	path := c.AccountsEndpoint + "/portins/" + portInID
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPut, path, &PortInResponse{}, update)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*PortInResponse), nil
+}
+
+// CancelPortIn cancels the port-in order.
+func (c *Client) CancelPortIn(ctx context.Context, portInID string) error {
+	path := c.AccountsEndpoint + "/portins/" + portInID
+	_, _, err := c.makeAccountsRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// GetPortInHistory returns the status changes of the port-in order.
+func (c *Client) GetPortInHistory(ctx context.Context, portInID string) ([]OrderHistoryItem, error) {
+	path := c.AccountsEndpoint + "/portins/" + portInID + "/history"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &OrderHistoryResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*OrderHistoryResponse).History, nil
+}
+
+// UploadLOA uploads the signed Letter of Authorization (like application/pdf) and returns its file name.
+func (c *Client) UploadLOA(ctx context.Context, portInID, contentType string, data []byte) (string, error) {
+	path := c.AccountsEndpoint + "/portins/" + portInID + "/loas?documentType=LOA"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodPost, path, &fileUploadResponse{}, &rawContent{contentType: contentType, data: data})
+	if err != nil {
+		return "", err
+	}
+	response := result.(*fileUploadResponse)
+	if response.ResultCode != 0 {
+		return "", fmt.Errorf("LOA upload failed: %s", response.ResultMessage)
+	}
+	return response.FileName, nil
+}
+
+// ListLOAs returns the file names of the documents uploaded to the port-in order.
+func (c *Client) ListLOAs(ctx context.Context, portInID string) ([]string, error) {
+	path := c.AccountsEndpoint + "/portins/" + portInID + "/loas"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &fileListResponse{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*fileListResponse).FileNames, nil
+}
+
+// DownloadLOA returns the content of the uploaded document.
+func (c *Client) DownloadLOA(ctx context.Context, portInID, fileName string) ([]byte, error) {
+	path := c.AccountsEndpoint + "/portins/" + portInID + "/loas/" + url.PathEscape(fileName)
+	var data []byte
+	if _, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
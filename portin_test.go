@@ -0,0 +1,271 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCheckPortability(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/lnpchecker?fullCheck=true", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<NumberPortabilityRequest><TnList><Tn>9195551212</Tn><Tn>4109255199</Tn></TnList></NumberPortabilityRequest>",
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<NumberPortabilityResponse>
+			<PortableNumbers>
+				<Tn>9195551212</Tn>
+			</PortableNumbers>
+			<PortType>AUTOMATED</PortType>
+			<SupportedRateCenters>
+				<RateCenterGroup>
+					<RateCenter>RALEIGH</RateCenter>
+					<City>RALEIGH</City>
+					<State>NC</State>
+					<LATA>426</LATA>
+					<Tiers>
+						<Tier>0</Tier>
+					</Tiers>
+					<TnList>
+						<Tn>9195551212</Tn>
+					</TnList>
+				</RateCenterGroup>
+			</SupportedRateCenters>
+			<UnsupportedRateCenters>
+				<RateCenterGroup>
+					<RateCenter>BALTIMORE</RateCenter>
+					<State>MD</State>
+					<TnList>
+						<Tn>4109255199</Tn>
+					</TnList>
+				</RateCenterGroup>
+			</UnsupportedRateCenters>
+			<SupportedLosingCarriers>
+				<LosingCarrierTnList>
+					<LosingCarrierSPID>9998</LosingCarrierSPID>
+					<LosingCarrierName>Test Losing Carrier L3</LosingCarrierName>
+					<LosingCarrierIsWireless>false</LosingCarrierIsWireless>
+					<LosingCarrierAccountNumberRequired>false</LosingCarrierAccountNumberRequired>
+					<LosingCarrierMinimumPortingInterval>5</LosingCarrierMinimumPortingInterval>
+					<TnList>
+						<Tn>9195551212</Tn>
+					</TnList>
+				</LosingCarrierTnList>
+			</SupportedLosingCarriers>
+		</NumberPortabilityResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.CheckPortability(context.Background(), []string{"9195551212", "4109255199"}, true)
+	if err != nil {
+		t.Errorf("Failed call of CheckPortability(): %v", err)
+		return
+	}
+	expect(t, result.PortableNumbers, []string{"9195551212"})
+	expect(t, result.PortType, "AUTOMATED")
+	expect(t, result.SupportedRateCenters[0].Tiers, []string{"0"})
+	expect(t, result.UnsupportedRateCenters[0].TnList, []string{"4109255199"})
+	expect(t, result.SupportedLosingCarriers[0].Name, "Test Losing Carrier L3")
+	expect(t, result.SupportedLosingCarriers[0].MinimumPortingInterval, "5")
+}
+
+func TestCreatePortIn(t *testing.T) {
+	focDate := time.Date(2019, 12, 2, 0, 0, 0, 0, time.UTC)
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/portins", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "<LnpOrder><CustomerOrderId>abc</CustomerOrderId><BillingTelephoneNumber>9195551212</BillingTelephoneNumber><Subscriber><SubscriberType>BUSINESS</SubscriberType><BusinessName>Company</BusinessName><ServiceAddress><HouseNumber>900</HouseNumber><StreetName>Main Campus</StreetName><City>Raleigh</City><StateCode>NC</StateCode><Zip>27606</Zip></ServiceAddress></Subscriber><LoaAuthorizingPerson>John Doe</LoaAuthorizingPerson><ListOfPhoneNumbers><PhoneNumber>9195551212</PhoneNumber></ListOfPhoneNumbers><SiteId>12345</SiteId><PeerId>123123</PeerId><RequestedFocDate>2019-12-02T00:00:00Z</RequestedFocDate><Triggered>false</Triggered></LnpOrder>",
+		StatusCodeToSend: http.StatusCreated,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<LnpOrderResponse>
+			<OrderId>d28b36f7-fa96-49eb-9556-a40fca49f7c6</OrderId>
+			<Status>
+				<Code>201</Code>
+				<Description>Order request received. Please use the order id to check the status of your order later.</Description>
+			</Status>
+			<ProcessingStatus>PENDING_DOCUMENTS</ProcessingStatus>
+			<CustomerOrderId>abc</CustomerOrderId>
+			<RequestedFocDate>2019-12-02T00:00:00.000Z</RequestedFocDate>
+			<ListOfPhoneNumbers>
+				<PhoneNumber>9195551212</PhoneNumber>
+			</ListOfPhoneNumbers>
+			<SiteId>12345</SiteId>
+			<PeerId>123123</PeerId>
+			<Triggered>false</Triggered>
+			<BillingType>PORTIN</BillingType>
+		</LnpOrderResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.CreatePortIn(context.Background(), &PortIn{
+		CustomerOrderID:        "abc",
+		BillingTelephoneNumber: "9195551212",
+		Subscriber: Subscriber{
+			SubscriberType: "BUSINESS",
+			BusinessName:   "Company",
+			ServiceAddress: Address{HouseNumber: "900", StreetName: "Main Campus", City: "Raleigh", StateCode: "NC", Zip: "27606"},
+		},
+		LoaAuthorizingPerson: "John Doe",
+		ListOfPhoneNumbers:   []string{"9195551212"},
+		SiteID:               "12345",
+		PeerID:               "123123",
+		RequestedFocDate:     &focDate,
+	})
+	if err != nil {
+		t.Errorf("Failed call of CreatePortIn(): %v", err)
+		return
+	}
+	expect(t, result.OrderID, "d28b36f7-fa96-49eb-9556-a40fca49f7c6")
+	expect(t, result.ProcessingStatus, PortInStatusPendingDocuments)
+	expect(t, result.Status.Code, "201")
+	expect(t, result.RequestedFocDate.Equal(focDate), true)
+	expect(t, result.ListOfPhoneNumbers, []string{"9195551212"})
+}
+
+func TestGetPortIn(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/portins/1-2-3-4", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<LnpOrderResponse>
+			<OrderId>1-2-3-4</OrderId>
+			<ProcessingStatus>FOC</ProcessingStatus>
+			<RequestedFocDate>2019-12-02T00:00:00.000Z</RequestedFocDate>
+			<ActualFocDate>2019-12-03T00:00:00.000Z</ActualFocDate>
+			<Errors>
+				<Code>7201</Code>
+				<Description>Requested FOC date is not available</Description>
+			</Errors>
+		</LnpOrderResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.GetPortIn(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of GetPortIn(): %v", err)
+		return
+	}
+	expect(t, result.ProcessingStatus, PortInStatusFOC)
+	expect(t, result.ActualFocDate.Equal(time.Date(2019, 12, 3, 0, 0, 0, 0, time.UTC)), true)
+	expect(t, result.Errors[0].Code, "7201")
+}
+
+func TestUpdatePortIn(t *testing.T) {
+	focDate := time.Date(2019, 12, 5, 0, 0, 0, 0, time.UTC)
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/portins/1-2-3-4", accountsPath, testAccountID),
+		Method:           http.MethodPut,
+		EstimatedContent: "<LnpOrderSupp><RequestedFocDate>2019-12-05T00:00:00Z</RequestedFocDate></LnpOrderSupp>",
+		ContentToSend:    `<LnpOrderResponse><OrderId>1-2-3-4</OrderId><ProcessingStatus>REQUESTED_SUPP</ProcessingStatus></LnpOrderResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.UpdatePortIn(context.Background(), "1-2-3-4", &PortInUpdate{RequestedFocDate: &focDate})
+	if err != nil {
+		t.Errorf("Failed call of UpdatePortIn(): %v", err)
+		return
+	}
+	expect(t, result.ProcessingStatus, PortInStatusRequestedSupp)
+}
+
+func TestCancelPortIn(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/portins/1-2-3-4", accountsPath, testAccountID),
+		Method:       http.MethodDelete,
+	}})
+	defer server.Close()
+	if err := api.CancelPortIn(context.Background(), "1-2-3-4"); err != nil {
+		t.Errorf("Failed call of CancelPortIn(): %v", err)
+	}
+}
+
+func TestGetPortInHistory(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/portins/1-2-3-4/history", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<OrderHistoryWrapper>
+			<OrderHistory>
+				<OrderDate>2019-11-05T13:48:43.238Z</OrderDate>
+				<Note>LOA required</Note>
+				<Author>System</Author>
+				<Status>PENDING_DOCUMENTS</Status>
+			</OrderHistory>
+			<OrderHistory>
+				<OrderDate>2019-11-06T10:00:00.000Z</OrderDate>
+				<Note>FOC received</Note>
+				<Author>System</Author>
+				<Status>FOC</Status>
+			</OrderHistory>
+		</OrderHistoryWrapper>`,
+	}})
+	defer server.Close()
+	result, err := api.GetPortInHistory(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of GetPortInHistory(): %v", err)
+		return
+	}
+	expect(t, len(result), 2)
+	expect(t, result[1].Status, PortInStatusFOC)
+}
+
+func TestUploadLOA(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("%s%s/portins/1-2-3-4/loas?documentType=LOA", accountsPath, testAccountID),
+		Method:           http.MethodPost,
+		EstimatedContent: "%PDF-1.4",
+		EstimatedHeaders: map[string]string{"Content-Type": "application/pdf"},
+		ContentToSend: `
+		<fileUploadResponse>
+			<filename>1-2-3-4-1.pdf</filename>
+			<resultCode>0</resultCode>
+			<resultMessage>LOA file uploaded successfully for order 1-2-3-4</resultMessage>
+		</fileUploadResponse>`,
+	}})
+	defer server.Close()
+	fileName, err := api.UploadLOA(context.Background(), "1-2-3-4", "application/pdf", []byte("%PDF-1.4"))
+	if err != nil {
+		t.Errorf("Failed call of UploadLOA(): %v", err)
+		return
+	}
+	expect(t, fileName, "1-2-3-4-1.pdf")
+}
+
+func TestListLOAs(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/portins/1-2-3-4/loas", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<fileListResponse>
+			<fileCount>2</fileCount>
+			<fileNames>1-2-3-4-1.pdf</fileNames>
+			<fileNames>1-2-3-4-2.pdf</fileNames>
+			<resultCode>0</resultCode>
+			<resultMessage>LOA file list successfully returned</resultMessage>
+		</fileListResponse>`,
+	}})
+	defer server.Close()
+	result, err := api.ListLOAs(context.Background(), "1-2-3-4")
+	if err != nil {
+		t.Errorf("Failed call of ListLOAs(): %v", err)
+		return
+	}
+	expect(t, result, []string{"1-2-3-4-1.pdf", "1-2-3-4-2.pdf"})
+}
+
+func TestDownloadLOA(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  fmt.Sprintf("%s%s/portins/1-2-3-4/loas/1-2-3-4-1.pdf", accountsPath, testAccountID),
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Content-Type": "application/pdf"},
+		ContentToSend: "%PDF-1.4",
+	}})
+	defer server.Close()
+	result, err := api.DownloadLOA(context.Background(), "1-2-3-4", "1-2-3-4-1.pdf")
+	if err != nil {
+		t.Errorf("Failed call of DownloadLOA(): %v", err)
+		return
+	}
+	expect(t, string(result), "%PDF-1.4\n")
+}
@@ -0,0 +1,237 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+)
+
+// MessagingLocation describes the messaging location (sip-peer) to provision.
+type MessagingLocation struct {
+	SiteID        string
+	PeerName      string
+	IsDefaultPeer bool
+	ApplicationID string
+	// SMS are the SMS settings (DefaultSMSFeatureSettings if nil).
+	SMS *SipPeerSmsFeature
+	// MMS are the MMS settings (MMS over HTTP if nil).
+	MMS *MmsFeature
+	// DisableMMS skips enabling MMS.
+	DisableMMS bool
+	// Quantity is the number of numbers to order in AreaCode or matching TollFreeMask (optional).
+	Quantity     int
+	AreaCode     string
+	TollFreeMask string
+}
+
+// ProvisionState records the steps done by ProvisionMessagingLocation.
+// Persist it (e.g. as JSON) from ProvisionOpts.OnProgress to resume the provisioning after a crash.
+type ProvisionState struct {
+	PeerID                string `json:"peerId,omitempty"`
+	SMSEnabled            bool   `json:"smsEnabled,omitempty"`
+	MMSEnabled            bool   `json:"mmsEnabled,omitempty"`
+	ApplicationAssociated bool   `json:"applicationAssociated,omitempty"`
+	OrderID               string `json:"orderId,omitempty"`
+	// Numbers are the ordered numbers.
+	Numbers   []string `json:"numbers,omitempty"`
+	Completed bool     `json:"completed,omitempty"`
+}
+
+// ProvisionOpts are the options of ProvisionMessagingLocation.
+type ProvisionOpts struct {
+	// OnProgress is called with the state after every step. Returning an error stops the provisioning.
+	OnProgress func(state ProvisionState) error
+	// NoRollback keeps the created resources on failure (to resume later).
+	NoRollback bool
+	// Wait are the options of waiting for the number order.
+	Wait WaitOpts
+}
+
+// ProvisionError is returned when a provisioning step fails.
+type ProvisionError struct {
+	// Step is the failed step (peer, sms, mms, application, order, wait, progress).
+	Step string
+	Err  error
+	// RollbackErr is the error of the rollback (if it failed).
+	RollbackErr error
+}
+
+func (e *ProvisionError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("provisioning failed at %s: %v (rollback failed: %v)", e.Step, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("provisioning failed at %s: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the error of the failed step.
+func (e *ProvisionError) Unwrap() error {
+	return e.Err
+}
+
+// ProvisionMessagingLocation creates the sip-peer, enables SMS and MMS, associates the application
+// and orders the numbers. Steps already done in state (from the previous run) are skipped.
+// The peer (matched by PeerName) and the order (matched by its CustomerOrderID) created by a run
+// which crashed before saving the state are reused, so they are never created twice.
+// On failure the created resources are removed (unless NoRollback is set) and the state is reset.
+func (c *Client) ProvisionMessagingLocation(ctx context.Context, location *MessagingLocation, state ProvisionState, opts ProvisionOpts) (ProvisionState, error) {
+	progress := func() error {
+		if opts.OnProgress == nil {
+			return nil
+		}
+		return opts.OnProgress(state)
+	}
+	steps := []struct {
+		name string
+		done func() bool
+		run  func() error
+	}{
+		{"peer", func() bool { return state.PeerID != "" }, func() (err error) {
+			peers, err := c.ListPeers(ctx, location.SiteID)
+			if err != nil {
+				return err
+			}
+			for _, peer := range peers {
+				if peer.PeerName == location.PeerName {
+					state.PeerID = peer.PeerID
+					return nil
+				}
+			}
+			state.PeerID, err = c.CreatePeer(ctx, location.ApplicationID, location.SiteID, location.PeerName, location.IsDefaultPeer)
+			return err
+		}},
+		{"sms", func() bool { return state.SMSEnabled }, func() error {
+			sms := location.SMS
+			if sms == nil {
+				sms = &SipPeerSmsFeature{SipPeerSmsFeatureSettings: DefaultSMSFeatureSettings}
+			}
+			_, err := c.EnableSMSFeature(ctx, location.SiteID, state.PeerID, sms)
+			state.SMSEnabled = err == nil
+			return err
+		}},
+		{"mms", func() bool { return state.MMSEnabled || location.DisableMMS }, func() error {
+			mms := location.MMS
+			if mms == nil {
+				mms = &MmsFeature{MmsSettings: MmsSettings{Protocol: "HTTP"}}
+			}
+			_, err := c.EnableMMSFeature(ctx, location.SiteID, state.PeerID, mms)
+			state.MMSEnabled = err == nil
+			return err
+		}},
+		{"application", func() bool { return state.ApplicationAssociated || location.ApplicationID == "" }, func() error {
+			_, err := c.AssociateApplication(ctx, location.SiteID, state.PeerID, location.ApplicationID)
+			state.ApplicationAssociated = err == nil
+			return err
+		}},
+		{"order", func() bool { return state.OrderID != "" || location.Quantity == 0 }, func() error {
+			order := &NumberOrder{SiteID: location.SiteID, PeerID: state.PeerID, CustomerOrderID: "provision-" + location.SiteID + "-" + state.PeerID}
+			orders := c.ListOrders(&OrdersFilter{CustomerOrderID: order.CustomerOrderID})
+			for orders.Next(ctx) {
+				// failed orders have no numbers so they are ordered again
+				if summary := orders.Order(); summary.OrderStatus != OrderStatusFailed {
+					state.OrderID = summary.OrderID
+					return nil
+				}
+			}
+			if err := orders.Err(); err != nil {
+				return err
+			}
+			if location.TollFreeMask != "" {
+				order.TollFreeWildCharSearchAndOrderType = &TollFreeWildCharSearchAndOrderType{Quantity: location.Quantity, TollFreeWildCardPattern: location.TollFreeMask}
+			} else {
+				order.AreaCodeSearchAndOrderType = &AreaCodeSearchAndOrderType{Quantity: location.Quantity, AreaCode: location.AreaCode}
+			}
+			response, err := c.OrderNumbers(ctx, order)
+			if err != nil {
+				return err
+			}
+			state.OrderID = response.Order.ID
+			return nil
+		}},
+		{"wait", func() bool { return state.OrderID == "" || state.Completed }, func() error {
+			result, err := c.WaitForOrder(ctx, state.OrderID, opts.Wait)
+			if err != nil {
+				return err
+			}
+			if result.Status == OrderStatusFailed {
+				// nothing has been ordered so it is safe to order again when resumed
+				state.OrderID = ""
+				return fmt.Errorf("order %s failed", result.Order.Order.ID)
+			}
+			state.Numbers = result.CompletedNumbers
+			return nil
+		}},
+	}
+	for _, step := range steps {
+		if step.done() {
+			continue
+		}
+		err := step.run()
+		name := step.name
+		if err == nil {
+			name = "progress"
+			err = progress()
+		}
+		if err != nil {
+			return c.failProvisioning(ctx, location, state, opts, name, err)
+		}
+	}
+	state.Completed = true
+	if err := progress(); err != nil {
+		return state, &ProvisionError{Step: "progress", Err: err}
+	}
+	return state, nil
+}
+
+// failProvisioning rolls back the steps done in state. The state is reported to OnProgress
+// after every rollback step so the persisted state doesn't refer to the removed resources.
+func (c *Client) failProvisioning(ctx context.Context, location *MessagingLocation, state ProvisionState, opts ProvisionOpts, step string, err error) (ProvisionState, error) {
+	provisionError := &ProvisionError{Step: step, Err: err}
+	if opts.NoRollback || state.OrderID != "" || len(state.Numbers) > 0 {
+		// a peer with (possibly pending) numbers can't be deleted
+		return state, provisionError
+	}
+	if ctx.Err() != nil {
+		// the rollback should not be interrupted by the cancelled context
+		ctx = context.Background()
+	}
+	rollback := []struct {
+		done func() bool
+		run  func() error
+	}{
+		{func() bool { return state.ApplicationAssociated }, func() error {
+			err := c.DisassociateApplication(ctx, location.SiteID, state.PeerID)
+			state.ApplicationAssociated = err != nil
+			return err
+		}},
+		{func() bool { return state.MMSEnabled }, func() error {
+			err := c.DisableMMS(ctx, location.SiteID, state.PeerID)
+			state.MMSEnabled = err != nil
+			return err
+		}},
+		{func() bool { return state.SMSEnabled }, func() error {
+			err := c.DisableSMS(ctx, location.SiteID, state.PeerID)
+			state.SMSEnabled = err != nil
+			return err
+		}},
+		{func() bool { return state.PeerID != "" }, func() error {
+			if err := c.DeletePeer(ctx, location.SiteID, state.PeerID); err != nil {
+				return err
+			}
+			state.PeerID = ""
+			return nil
+		}},
+	}
+	for _, step := range rollback {
+		if !step.done() {
+			continue
+		}
+		if provisionError.RollbackErr = step.run(); provisionError.RollbackErr != nil {
+			return state, provisionError
+		}
+		if opts.OnProgress != nil {
+			if provisionError.RollbackErr = opts.OnProgress(state); provisionError.RollbackErr != nil {
+				return state, provisionError
+			}
+		}
+	}
+	return state, provisionError
+}
@@ -0,0 +1,234 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func provisionHandlers(siteID, peerID string) []RequestHandler {
+	peerPath := fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID)
+	return []RequestHandler{
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites/%s/sippeers", accountsPath, testAccountID, siteID),
+			ContentToSend: `<TNSipPeersResponse><SipPeers></SipPeers></TNSipPeersResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers", accountsPath, testAccountID, siteID),
+			Method:       http.MethodPost,
+			HeadersToSend: map[string]string{
+				"Location": fmt.Sprintf("https://dashboard.bandwidth.com:443/v1.0/accounts/%s/sites/%s/sippeers/%s", testAccountID, siteID, peerID),
+			},
+		},
+		RequestHandler{PathAndQuery: peerPath + "/products/messaging/features/sms", Method: http.MethodPost},
+		RequestHandler{PathAndQuery: peerPath + "/products/messaging/features/mms", Method: http.MethodPost},
+		RequestHandler{PathAndQuery: peerPath + "/products/messaging/applicationSettings", Method: http.MethodPut},
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/orders?customerOrderId=provision-%s-%s&page=1&size=300", accountsPath, testAccountID, siteID, peerID),
+			ContentToSend: `<ResponseSelectWrapper><ListOrderIdUserIdDate><TotalCount>0</TotalCount></ListOrderIdUserIdDate></ResponseSelectWrapper>`,
+		},
+		RequestHandler{
+			PathAndQuery:     fmt.Sprintf("%s%s/orders", accountsPath, testAccountID),
+			Method:           http.MethodPost,
+			EstimatedContent: "<Order><SiteId>12345</SiteId><PeerId>678</PeerId><PartialAllowed>false</PartialAllowed><CustomerOrderId>provision-12345-678</CustomerOrderId><AreaCodeSearchAndOrderType><AreaCode>734</AreaCode><Quantity>1</Quantity></AreaCodeSearchAndOrderType></Order>",
+			ContentToSend:    `<OrderResponse><Order><id>1-2-3-4</id></Order><OrderStatus>RECEIVED</OrderStatus></OrderResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/orders/1-2-3-4", accountsPath, testAccountID),
+			ContentToSend: `<OrderResponse>
+				<OrderStatus>COMPLETE</OrderStatus>
+				<CompletedNumbers><TelephoneNumber><FullNumber>7341231234</FullNumber></TelephoneNumber></CompletedNumbers>
+			</OrderResponse>`,
+		},
+	}
+}
+
+func TestProvisionMessagingLocation(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, provisionHandlers(siteID, peerID))
+	defer server.Close()
+	var states []ProvisionState
+	state, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:        siteID,
+		PeerName:      "test peer",
+		ApplicationID: testApplicationID,
+		Quantity:      1,
+		AreaCode:      "734",
+	}, ProvisionState{}, ProvisionOpts{
+		Wait: WaitOpts{Interval: time.Millisecond},
+		OnProgress: func(state ProvisionState) error {
+			states = append(states, state)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed call of ProvisionMessagingLocation(): %v", err)
+	}
+	expect(t, state, ProvisionState{PeerID: peerID, SMSEnabled: true, MMSEnabled: true, ApplicationAssociated: true,
+		OrderID: "1-2-3-4", Numbers: []string{"7341231234"}, Completed: true})
+	expect(t, len(states), 7)
+	expect(t, states[0], ProvisionState{PeerID: peerID})
+}
+
+func TestProvisionMessagingLocationResume(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	// the peer can't be created again
+	server, api := startMockServer(t, provisionHandlers(siteID, peerID)[3:5])
+	defer server.Close()
+	state, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:        siteID,
+		ApplicationID: testApplicationID,
+	}, ProvisionState{PeerID: peerID, SMSEnabled: true}, ProvisionOpts{})
+	if err != nil {
+		t.Fatalf("Failed call of ProvisionMessagingLocation(): %v", err)
+	}
+	expect(t, state, ProvisionState{PeerID: peerID, SMSEnabled: true, MMSEnabled: true, ApplicationAssociated: true, Completed: true})
+}
+
+func TestProvisionMessagingLocationResumeAfterCrash(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	handlers := provisionHandlers(siteID, peerID)
+	// the peer and the order were created but the state wasn't saved
+	handlers[0].ContentToSend = fmt.Sprintf(`<TNSipPeersResponse><SipPeers><SipPeer><PeerId>%s</PeerId><PeerName>test peer</PeerName></SipPeer></SipPeers></TNSipPeersResponse>`, peerID)
+	handlers[5].ContentToSend = `<ResponseSelectWrapper><ListOrderIdUserIdDate>
+		<TotalCount>2</TotalCount>
+		<OrderIdUserIdDate><orderId>0-0-0-0</orderId><OrderStatus>FAILED</OrderStatus></OrderIdUserIdDate>
+		<OrderIdUserIdDate><orderId>1-2-3-4</orderId><OrderStatus>RECEIVED</OrderStatus></OrderIdUserIdDate>
+	</ListOrderIdUserIdDate></ResponseSelectWrapper>`
+	server, api := startMockServer(t, append(handlers[:1], handlers[2:]...))
+	defer server.Close()
+	state, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:   siteID,
+		PeerName: "test peer",
+		Quantity: 1,
+		AreaCode: "734",
+	}, ProvisionState{}, ProvisionOpts{Wait: WaitOpts{Interval: time.Millisecond}})
+	if err != nil {
+		t.Fatalf("Failed call of ProvisionMessagingLocation(): %v", err)
+	}
+	expect(t, state.PeerID, peerID)
+	expect(t, state.OrderID, "1-2-3-4")
+	expect(t, state.Numbers, []string{"7341231234"})
+}
+
+func TestProvisionMessagingLocationProgressFailed(t *testing.T) {
+	api := getAPI("http://localhost")
+	progressError := errors.New("disk full")
+	_, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:     "12345",
+		DisableMMS: true,
+	}, ProvisionState{PeerID: "678", SMSEnabled: true}, ProvisionOpts{OnProgress: func(state ProvisionState) error {
+		return progressError
+	}})
+	var provisionError *ProvisionError
+	if !errors.As(err, &provisionError) {
+		t.Fatalf("Expected *ProvisionError - Got %v", err)
+	}
+	expect(t, provisionError.Step, "progress")
+	expect(t, errors.Is(err, progressError), true)
+}
+
+func TestProvisionMessagingLocationRollback(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	peerPath := fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID)
+	handlers := provisionHandlers(siteID, peerID)[:3]
+	handlers = append(handlers,
+		RequestHandler{
+			PathAndQuery:     peerPath + "/products/messaging/features/mms",
+			Method:           http.MethodPost,
+			StatusCodeToSend: http.StatusBadRequest,
+			ContentToSend:    `<MmsFeatureResponse><ResponseStatus><ErrorCode>1234</ErrorCode><Description>invalid</Description></ResponseStatus></MmsFeatureResponse>`,
+		},
+		RequestHandler{PathAndQuery: peerPath + "/products/messaging/features/sms", Method: http.MethodDelete},
+		RequestHandler{PathAndQuery: peerPath, Method: http.MethodDelete},
+	)
+	server, api := startMockServer(t, handlers)
+	defer server.Close()
+	var states []ProvisionState
+	state, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:   siteID,
+		PeerName: "test peer",
+	}, ProvisionState{}, ProvisionOpts{OnProgress: func(state ProvisionState) error {
+		states = append(states, state)
+		return nil
+	}})
+	var provisionError *ProvisionError
+	if !errors.As(err, &provisionError) {
+		t.Fatalf("Expected *ProvisionError - Got %v", err)
+	}
+	expect(t, provisionError.Step, "mms")
+	expectNil(t, provisionError.RollbackErr)
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Expected *APIError - Got %v", err)
+	}
+	expect(t, apiError.Code, "1234")
+	expect(t, state, ProvisionState{})
+	// the rolled back state is reported so it can't be resumed with the deleted peer
+	expect(t, states, []ProvisionState{
+		ProvisionState{PeerID: peerID},
+		ProvisionState{PeerID: peerID, SMSEnabled: true},
+		ProvisionState{PeerID: peerID},
+		ProvisionState{},
+	})
+}
+
+func TestProvisionMessagingLocationFailedOrder(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	peerPath := fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID)
+	handlers := provisionHandlers(siteID, peerID)[:7]
+	handlers = append(handlers,
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/orders/1-2-3-4", accountsPath, testAccountID),
+			ContentToSend: `<OrderResponse><Order><id>1-2-3-4</id></Order><OrderStatus>FAILED</OrderStatus></OrderResponse>`,
+		},
+		RequestHandler{PathAndQuery: peerPath + "/products/messaging/features/mms", Method: http.MethodDelete},
+		RequestHandler{PathAndQuery: peerPath + "/products/messaging/features/sms", Method: http.MethodDelete},
+		RequestHandler{PathAndQuery: peerPath, Method: http.MethodDelete},
+	)
+	server, api := startMockServer(t, handlers)
+	defer server.Close()
+	var last ProvisionState
+	state, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:        siteID,
+		PeerName:      "test peer",
+		ApplicationID: testApplicationID,
+		Quantity:      1,
+		AreaCode:      "734",
+	}, ProvisionState{}, ProvisionOpts{
+		Wait: WaitOpts{Interval: time.Millisecond},
+		OnProgress: func(state ProvisionState) error {
+			last = state
+			return nil
+		},
+	})
+	var provisionError *ProvisionError
+	if !errors.As(err, &provisionError) {
+		t.Fatalf("Expected *ProvisionError - Got %v", err)
+	}
+	expect(t, provisionError.Step, "wait")
+	expectNil(t, provisionError.RollbackErr)
+	expect(t, state, ProvisionState{})
+	expect(t, last, ProvisionState{})
+}
+
+func TestProvisionMessagingLocationNoRollback(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, provisionHandlers(siteID, peerID)[:3])
+	defer server.Close()
+	state, err := api.ProvisionMessagingLocation(context.Background(), &MessagingLocation{
+		SiteID:   siteID,
+		PeerName: "test peer",
+	}, ProvisionState{}, ProvisionOpts{NoRollback: true})
+	expect(t, err.(*ProvisionError).Step, "mms")
+	expect(t, state, ProvisionState{PeerID: peerID, SMSEnabled: true})
+}
@@ -0,0 +1,63 @@
+package bandwidth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(&RateLimit{Rate: 100, Burst: 2})
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := bucket.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	// 2 requests are allowed at once, the rest should wait 10ms each
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Requests are not limited: %v", elapsed)
+	}
+}
+
+func TestTokenBucketContextCancelled(t *testing.T) {
+	bucket := newTokenBucket(&RateLimit{Rate: 0.1})
+	expectNil(t, bucket.Wait(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	expect(t, bucket.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestNewTokenBucketDisabled(t *testing.T) {
+	expect(t, newTokenBucket(nil), (*tokenBucket)(nil))
+	expect(t, newTokenBucket(&RateLimit{}), (*tokenBucket)(nil))
+	var bucket *tokenBucket
+	expectNil(t, bucket.Wait(context.Background()))
+}
+
+func TestMakeRequestRateLimited(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/api/v2/users/123/messages",
+		ContentToSend: `{"test": "test"}`}})
+	defer server.Close()
+	api.verbose = false
+	api.limiters[messagingRequest] = newTokenBucket(&RateLimit{Rate: 50})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, _, err := api.makeMessagingRequest(context.Background(), http.MethodGet, api.MessagingEndpoint, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Requests are not limited: %v", elapsed)
+	}
+}
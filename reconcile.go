@@ -0,0 +1,431 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// InfrastructureSpec is the desired state of the messaging infrastructure.
+// Resources are matched by name. Resources missing in the spec are never deleted.
+type InfrastructureSpec struct {
+	Sites []SiteSpec `json:"sites"`
+}
+
+// SiteSpec is the desired state of a site.
+type SiteSpec struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Address     *AddressSpec `json:"address,omitempty"`
+	Peers       []PeerSpec   `json:"peers,omitempty"`
+}
+
+// PeerSpec is the desired state of a sip-peer (aka location).
+type PeerSpec struct {
+	Name      string `json:"name"`
+	IsDefault bool   `json:"isDefault,omitempty"`
+	// SMS are the SMS settings. SMS is disabled if nil.
+	SMS *SMSSpec `json:"sms,omitempty"`
+	// MMS enables MMS over HTTP. MMS is disabled if false.
+	MMS bool `json:"mms,omitempty"`
+	// ApplicationID is the associated messaging application. The association is not managed if empty.
+	ApplicationID string `json:"applicationId,omitempty"`
+	// Numbers is the minimum count of numbers of the peer. Missing numbers are ordered
+	// in AreaCode or matching TollFreeMask. Extra numbers are never disconnected.
+	Numbers      int    `json:"numbers,omitempty"`
+	AreaCode     string `json:"areaCode,omitempty"`
+	TollFreeMask string `json:"tollFreeMask,omitempty"`
+}
+
+// AddressSpec is the address of a site. Empty fields aren't compared and keep the live value.
+type AddressSpec struct {
+	HouseNumber     string `json:"houseNumber,omitempty"`
+	HousePrefix     string `json:"housePrefix,omitempty"`
+	HouseSuffix     string `json:"houseSuffix,omitempty"`
+	PreDirectional  string `json:"preDirectional,omitempty"`
+	StreetName      string `json:"streetName,omitempty"`
+	StreetSuffix    string `json:"streetSuffix,omitempty"`
+	PostDirectional string `json:"postDirectional,omitempty"`
+	AddressLine2    string `json:"addressLine2,omitempty"`
+	City            string `json:"city,omitempty"`
+	StateCode       string `json:"stateCode,omitempty"`
+	Zip             string `json:"zip,omitempty"`
+	PlusFour        string `json:"plusFour,omitempty"`
+	County          string `json:"county,omitempty"`
+	Country         string `json:"country,omitempty"`
+	AddressType     string `json:"addressType,omitempty"`
+}
+
+// applyTo sets the non-empty fields of the spec on the address and returns whether it changed.
+func (a *AddressSpec) applyTo(address *Address) bool {
+	changed := false
+	for _, field := range []struct {
+		spec string
+		live *string
+	}{
+		{a.HouseNumber, &address.HouseNumber},
+		{a.HousePrefix, &address.HousePrefix},
+		{a.HouseSuffix, &address.HouseSuffix},
+		{a.PreDirectional, &address.PreDirectional},
+		{a.StreetName, &address.StreetName},
+		{a.StreetSuffix, &address.StreetSuffix},
+		{a.PostDirectional, &address.PostDirectional},
+		{a.AddressLine2, &address.AddressLine2},
+		{a.City, &address.City},
+		{a.StateCode, &address.StateCode},
+		{a.Zip, &address.Zip},
+		{a.PlusFour, &address.PlusFour},
+		{a.County, &address.County},
+		{a.Country, &address.Country},
+		{a.AddressType, &address.AddressType},
+	} {
+		if field.spec != "" && *field.live != field.spec {
+			*field.live = field.spec
+			changed = true
+		}
+	}
+	return changed
+}
+
+// SMSSpec are the SMS settings of a sip-peer. Empty strings aren't compared and keep the live value.
+type SMSSpec struct {
+	TollFree    bool   `json:"tollFree,omitempty"`
+	ShortCode   bool   `json:"shortCode,omitempty"`
+	A2pLongCode string `json:"a2pLongCode,omitempty"`
+	// Protocol is HTTP (default) or SMPP.
+	Protocol string `json:"protocol,omitempty"`
+	Zone1    bool   `json:"zone1,omitempty"`
+	Zone2    bool   `json:"zone2,omitempty"`
+	Zone3    bool   `json:"zone3,omitempty"`
+	Zone4    bool   `json:"zone4,omitempty"`
+	Zone5    bool   `json:"zone5,omitempty"`
+}
+
+// applyTo sets the spec on the settings and returns whether they changed.
+func (s *SMSSpec) applyTo(settings *SipPeerSmsFeatureSettings) bool {
+	changed := false
+	for _, field := range []struct {
+		spec bool
+		live *bool
+	}{
+		{s.TollFree, &settings.TollFree},
+		{s.ShortCode, &settings.ShortCode},
+		{s.Zone1, &settings.Zone1},
+		{s.Zone2, &settings.Zone2},
+		{s.Zone3, &settings.Zone3},
+		{s.Zone4, &settings.Zone4},
+		{s.Zone5, &settings.Zone5},
+	} {
+		if *field.live != field.spec {
+			*field.live = field.spec
+			changed = true
+		}
+	}
+	for _, field := range []struct {
+		spec string
+		live *string
+	}{
+		{s.A2pLongCode, &settings.A2pLongCode},
+		{s.Protocol, &settings.Protocol},
+	} {
+		if field.spec != "" && *field.live != field.spec {
+			*field.live = field.spec
+			changed = true
+		}
+	}
+	return changed
+}
+
+// ParseInfrastructureSpec parses the spec from JSON (YAML is not supported).
+// The keys are camelCase and unknown keys are rejected.
+func ParseInfrastructureSpec(data []byte) (*InfrastructureSpec, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	spec := &InfrastructureSpec{}
+	if err := decoder.Decode(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Kinds of the planned actions.
+const (
+	ActionCreateSite           = "create-site"
+	ActionUpdateSite           = "update-site"
+	ActionCreatePeer           = "create-peer"
+	ActionEnableSMS            = "enable-sms"
+	ActionUpdateSMS            = "update-sms"
+	ActionDisableSMS           = "disable-sms"
+	ActionEnableMMS            = "enable-mms"
+	ActionDisableMMS           = "disable-mms"
+	ActionAssociateApplication = "associate-application"
+	ActionOrderNumbers         = "order-numbers"
+)
+
+// Action is a change planned by PlanInfrastructure.
+type Action struct {
+	Kind        string
+	Site        string
+	Peer        string
+	Description string
+	apply       func(ctx context.Context) error
+}
+
+func (a Action) String() string {
+	target := a.Site
+	if a.Peer != "" {
+		target += "/" + a.Peer
+	}
+	return fmt.Sprintf("%s %s: %s", a.Kind, target, a.Description)
+}
+
+// Plan is the list of changes needed to reach the desired state.
+type Plan struct {
+	Actions []Action
+}
+
+func (p *Plan) String() string {
+	if len(p.Actions) == 0 {
+		return "no changes"
+	}
+	lines := make([]string, len(p.Actions))
+	for i, action := range p.Actions {
+		lines[i] = action.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ref is the ID of the resource which may be created by a previous action.
+type ref struct {
+	id string
+}
+
+// PlanInfrastructure compares the spec with the live state and returns the changes to apply.
+// Only the fields set in the spec are compared, so the plan of the applied spec has no changes.
+// Nothing is changed, so it can be used as a dry-run.
+func (c *Client) PlanInfrastructure(ctx context.Context, spec *InfrastructureSpec) (*Plan, error) {
+	sites, err := c.ListSites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	liveSites := make(map[string]Site)
+	for _, site := range sites {
+		liveSites[site.Name] = site
+	}
+	associations := make(map[string]map[string]bool)
+	plan := &Plan{}
+	for _, siteSpec := range spec.Sites {
+		siteSpec := siteSpec
+		site, exists := liveSites[siteSpec.Name]
+		siteRef := &ref{id: site.ID}
+		if !exists {
+			desired := Site{Name: siteSpec.Name, Description: siteSpec.Description}
+			if siteSpec.Address != nil {
+				desired.Address = &Address{}
+				siteSpec.Address.applyTo(desired.Address)
+			}
+			plan.Actions = append(plan.Actions, Action{Kind: ActionCreateSite, Site: siteSpec.Name, Description: "create site",
+				apply: func(ctx context.Context) (err error) {
+					siteRef.id, err = c.CreateSite(ctx, &desired)
+					return err
+				}})
+		} else {
+			// the list has only the names, the address comes with the site
+			live, err := c.GetSite(ctx, site.ID)
+			if err != nil {
+				return nil, err
+			}
+			desired := *live
+			changed := false
+			if siteSpec.Description != "" && desired.Description != siteSpec.Description {
+				desired.Description = siteSpec.Description
+				changed = true
+			}
+			if siteSpec.Address != nil {
+				address := Address{}
+				if live.Address != nil {
+					address = *live.Address
+				}
+				if siteSpec.Address.applyTo(&address) {
+					desired.Address = &address
+					changed = true
+				}
+			}
+			if changed {
+				plan.Actions = append(plan.Actions, Action{Kind: ActionUpdateSite, Site: siteSpec.Name, Description: "update site",
+					apply: func(ctx context.Context) error {
+						return c.UpdateSite(ctx, siteRef.id, &desired)
+					}})
+			}
+		}
+		livePeers := make(map[string]SipPeer)
+		if exists {
+			peers, err := c.ListPeers(ctx, site.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, peer := range peers {
+				livePeers[peer.PeerName] = peer
+			}
+		}
+		for _, peerSpec := range siteSpec.Peers {
+			actions, err := c.planPeer(ctx, siteSpec.Name, siteRef, peerSpec, livePeers, associations)
+			if err != nil {
+				return nil, err
+			}
+			plan.Actions = append(plan.Actions, actions...)
+		}
+	}
+	return plan, nil
+}
+
+func (c *Client) planPeer(ctx context.Context, siteName string, siteRef *ref, spec PeerSpec, livePeers map[string]SipPeer, associations map[string]map[string]bool) ([]Action, error) {
+	var actions []Action
+	add := func(kind, description string, apply func(ctx context.Context) error) {
+		actions = append(actions, Action{Kind: kind, Site: siteName, Peer: spec.Name, Description: description, apply: apply})
+	}
+	peer, exists := livePeers[spec.Name]
+	peerRef := &ref{id: peer.PeerID}
+	if !exists {
+		add(ActionCreatePeer, "create sip-peer", func(ctx context.Context) (err error) {
+			peerRef.id, err = c.CreatePeer(ctx, spec.ApplicationID, siteRef.id, spec.Name, spec.IsDefault)
+			return err
+		})
+	}
+
+	var liveSMS *SipPeerSmsFeatureSettings
+	liveMMS := false
+	liveNumbers := 0
+	if exists {
+		sms, err := c.GetSMSFeature(ctx, siteRef.id, peerRef.id)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			liveSMS = &sms.SipPeerSmsFeature.SipPeerSmsFeatureSettings
+		}
+		_, err = c.GetMMSFeature(ctx, siteRef.id, peerRef.id)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		liveMMS = err == nil
+		numbers := c.ListPeerNumbers(siteRef.id, peerRef.id)
+		for numbers.Next(ctx) {
+			liveNumbers++
+		}
+		if err := numbers.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	desiredSMS := SipPeerSmsFeatureSettings{Protocol: "HTTP"}
+	if liveSMS != nil {
+		desiredSMS = *liveSMS
+	}
+	switch {
+	case spec.SMS != nil && liveSMS == nil:
+		spec.SMS.applyTo(&desiredSMS)
+		add(ActionEnableSMS, "enable SMS", func(ctx context.Context) error {
+			_, err := c.EnableSMSFeature(ctx, siteRef.id, peerRef.id, &SipPeerSmsFeature{SipPeerSmsFeatureSettings: desiredSMS})
+			return err
+		})
+	case spec.SMS != nil && spec.SMS.applyTo(&desiredSMS):
+		add(ActionUpdateSMS, "update SMS settings", func(ctx context.Context) error {
+			_, err := c.UpdateSMSFeature(ctx, siteRef.id, peerRef.id, &SipPeerSmsFeature{SipPeerSmsFeatureSettings: desiredSMS})
+			return err
+		})
+	case spec.SMS == nil && liveSMS != nil:
+		add(ActionDisableSMS, "disable SMS", func(ctx context.Context) error {
+			return c.DisableSMS(ctx, siteRef.id, peerRef.id)
+		})
+	}
+
+	if spec.MMS && !liveMMS {
+		add(ActionEnableMMS, "enable MMS", func(ctx context.Context) error {
+			_, err := c.EnableMMS(ctx, siteRef.id, peerRef.id)
+			return err
+		})
+	} else if !spec.MMS && liveMMS {
+		add(ActionDisableMMS, "disable MMS", func(ctx context.Context) error {
+			return c.DisableMMS(ctx, siteRef.id, peerRef.id)
+		})
+	}
+
+	if spec.ApplicationID != "" {
+		associated := false
+		if exists {
+			peers, ok := associations[spec.ApplicationID]
+			if !ok {
+				peers = make(map[string]bool)
+				result, err := c.GetAssociatedPeers(ctx, spec.ApplicationID)
+				if err != nil {
+					return nil, err
+				}
+				for _, peer := range result.Peers.Associated {
+					peers[peer.SiteID+"/"+peer.PeerID] = true
+				}
+				associations[spec.ApplicationID] = peers
+			}
+			associated = peers[siteRef.id+"/"+peerRef.id]
+		}
+		if !associated {
+			add(ActionAssociateApplication, "associate application "+spec.ApplicationID, func(ctx context.Context) error {
+				_, err := c.AssociateApplication(ctx, siteRef.id, peerRef.id, spec.ApplicationID)
+				return err
+			})
+		}
+	}
+
+	if missing := spec.Numbers - liveNumbers; missing > 0 {
+		add(ActionOrderNumbers, fmt.Sprintf("order %d number(s)", missing), func(ctx context.Context) error {
+			var order *OrderResponse
+			var err error
+			if spec.TollFreeMask != "" {
+				order, err = c.OrderTollFreeNumbers(ctx, siteRef.id, peerRef.id, spec.TollFreeMask, missing)
+			} else {
+				order, err = c.OrderNumbersByAreaCode(ctx, siteRef.id, peerRef.id, spec.AreaCode, missing)
+			}
+			if err != nil {
+				return err
+			}
+			result, err := c.WaitForOrder(ctx, order.Order.ID, WaitOpts{})
+			if err != nil {
+				return err
+			}
+			if result.Status != OrderStatusComplete {
+				return fmt.Errorf("order %s is %s", order.Order.ID, result.Status)
+			}
+			return nil
+		})
+	}
+	return actions, nil
+}
+
+// ApplyPlan applies the planned actions in order. It stops at the first failed action.
+func (c *Client) ApplyPlan(ctx context.Context, plan *Plan) error {
+	for _, action := range plan.Actions {
+		if err := action.apply(ctx); err != nil {
+			return fmt.Errorf("%v: %w", action, err)
+		}
+	}
+	return nil
+}
+
+// Reconcile plans the changes needed to reach the spec and applies them unless dryRun is set.
+// It returns the plan.
+func (c *Client) Reconcile(ctx context.Context, spec *InfrastructureSpec, dryRun bool) (*Plan, error) {
+	plan, err := c.PlanInfrastructure(ctx, spec)
+	if err != nil || dryRun {
+		return plan, err
+	}
+	return plan, c.ApplyPlan(ctx, plan)
+}
+
+func isNotFound(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,215 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseInfrastructureSpec(t *testing.T) {
+	spec, err := ParseInfrastructureSpec([]byte(`{
+		"sites": [{
+			"name": "site1",
+			"address": {"city": "Raleigh", "stateCode": "NC"},
+			"peers": [{"name": "peer1", "sms": {"tollFree": true, "protocol": "HTTP"}, "mms": true, "numbers": 2, "areaCode": "734"}]
+		}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, spec.Sites[0].Address.City, "Raleigh")
+	expect(t, spec.Sites[0].Peers[0].SMS.TollFree, true)
+	expect(t, spec.Sites[0].Peers[0].Numbers, 2)
+	_, err = ParseInfrastructureSpec([]byte(`{"sites": [{"unknown": 1}]}`))
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestPlanInfrastructure(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	peerPath := fmt.Sprintf("%s%s/sites/%s/sippeers/%s", accountsPath, testAccountID, siteID, peerID)
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+			ContentToSend: fmt.Sprintf(`<SitesResponse><Sites><Site><Id>%s</Id><Name>site1</Name></Site></Sites></SitesResponse>`, siteID),
+		},
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites/%s", accountsPath, testAccountID, siteID),
+			ContentToSend: fmt.Sprintf(`<SiteResponse><Site><Id>%s</Id><Name>site1</Name></Site></SiteResponse>`, siteID),
+		},
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites/%s/sippeers", accountsPath, testAccountID, siteID),
+			ContentToSend: fmt.Sprintf(`<TNSipPeersResponse><SipPeers><SipPeer><PeerId>%s</PeerId><PeerName>peer1</PeerName></SipPeer></SipPeers></TNSipPeersResponse>`, peerID),
+		},
+		RequestHandler{
+			PathAndQuery: peerPath + "/products/messaging/features/sms",
+			ContentToSend: `<SipPeerSmsFeatureResponse><SipPeerSmsFeature><SipPeerSmsFeatureSettings>
+				<TollFree>false</TollFree><Protocol>HTTP</Protocol>
+			</SipPeerSmsFeatureSettings></SipPeerSmsFeature></SipPeerSmsFeatureResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery:     peerPath + "/products/messaging/features/mms",
+			StatusCodeToSend: http.StatusNotFound,
+		},
+		RequestHandler{
+			PathAndQuery: peerPath + "/tns",
+			ContentToSend: `<SipPeerTelephoneNumbersResponse><SipPeerTelephoneNumbers>
+				<SipPeerTelephoneNumber><FullNumber>7341231234</FullNumber></SipPeerTelephoneNumber>
+			</SipPeerTelephoneNumbers></SipPeerTelephoneNumbersResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/applications/%s/associatedsippeers", accountsPath, testAccountID, testApplicationID),
+			ContentToSend: `<AssociatedSipPeersResponse><AssociatedSipPeers></AssociatedSipPeers></AssociatedSipPeersResponse>`,
+		},
+	})
+	defer server.Close()
+	plan, err := api.PlanInfrastructure(context.Background(), &InfrastructureSpec{Sites: []SiteSpec{
+		SiteSpec{Name: "site1", Peers: []PeerSpec{PeerSpec{
+			Name:          "peer1",
+			SMS:           &SMSSpec{TollFree: true},
+			MMS:           true,
+			ApplicationID: testApplicationID,
+			Numbers:       2,
+			AreaCode:      "734",
+		}}},
+		SiteSpec{Name: "site2", Peers: []PeerSpec{PeerSpec{Name: "peer2", MMS: true}}},
+	}})
+	if err != nil {
+		t.Fatalf("Failed call of PlanInfrastructure(): %v", err)
+	}
+	var kinds []string
+	for _, action := range plan.Actions {
+		kinds = append(kinds, action.Kind)
+	}
+	expect(t, kinds, []string{ActionUpdateSMS, ActionEnableMMS, ActionAssociateApplication, ActionOrderNumbers,
+		ActionCreateSite, ActionCreatePeer, ActionEnableMMS})
+	expect(t, plan.Actions[3].String(), "order-numbers site1/peer1: order 1 number(s)")
+}
+
+func TestPlanInfrastructureNoChanges(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	sitePath := fmt.Sprintf("%s%s/sites/%s", accountsPath, testAccountID, siteID)
+	peerPath := fmt.Sprintf("%s/sippeers/%s", sitePath, peerID)
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+			ContentToSend: fmt.Sprintf(`<SitesResponse><Sites><Site><Id>%s</Id><Name>site1</Name></Site></Sites></SitesResponse>`, siteID),
+		},
+		RequestHandler{
+			PathAndQuery: sitePath,
+			ContentToSend: fmt.Sprintf(`<SiteResponse><Site><Id>%s</Id><Name>site1</Name><Description>Main</Description>
+				<Address><City>Raleigh</City><StateCode>NC</StateCode><AddressType>Service</AddressType></Address>
+			</Site></SiteResponse>`, siteID),
+		},
+		RequestHandler{
+			PathAndQuery:  sitePath + "/sippeers",
+			ContentToSend: fmt.Sprintf(`<TNSipPeersResponse><SipPeers><SipPeer><PeerId>%s</PeerId><PeerName>peer1</PeerName></SipPeer></SipPeers></TNSipPeersResponse>`, peerID),
+		},
+		RequestHandler{
+			PathAndQuery: peerPath + "/products/messaging/features/sms",
+			ContentToSend: `<SipPeerSmsFeatureResponse><SipPeerSmsFeature><SipPeerSmsFeatureSettings>
+				<TollFree>true</TollFree><A2pLongCode>DefaultOff</A2pLongCode><Protocol>HTTP</Protocol>
+			</SipPeerSmsFeatureSettings></SipPeerSmsFeature></SipPeerSmsFeatureResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery:     peerPath + "/products/messaging/features/mms",
+			StatusCodeToSend: http.StatusNotFound,
+		},
+		RequestHandler{
+			PathAndQuery:  peerPath + "/tns",
+			ContentToSend: `<SipPeerTelephoneNumbersResponse><SipPeerTelephoneNumbers></SipPeerTelephoneNumbers></SipPeerTelephoneNumbersResponse>`,
+		},
+	})
+	defer server.Close()
+	spec := &InfrastructureSpec{Sites: []SiteSpec{SiteSpec{
+		Name:    "site1",
+		Address: &AddressSpec{City: "Raleigh", StateCode: "NC"},
+		Peers:   []PeerSpec{PeerSpec{Name: "peer1", SMS: &SMSSpec{TollFree: true}}},
+	}}}
+	for i := 0; i < 2; i++ {
+		plan, err := api.PlanInfrastructure(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("Failed call of PlanInfrastructure(): %v", err)
+		}
+		expect(t, plan.String(), "no changes")
+	}
+}
+
+func TestReconcileUpdateSite(t *testing.T) {
+	siteID := "12345"
+	sitePath := fmt.Sprintf("%s%s/sites/%s", accountsPath, testAccountID, siteID)
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+			ContentToSend: fmt.Sprintf(`<SitesResponse><Sites><Site><Id>%s</Id><Name>site1</Name></Site></Sites></SitesResponse>`, siteID),
+		},
+		RequestHandler{
+			PathAndQuery: sitePath,
+			ContentToSend: fmt.Sprintf(`<SiteResponse><Site><Id>%s</Id><Name>site1</Name>
+				<Address><City>Raleigh</City><StateCode>NC</StateCode><AddressType>Service</AddressType></Address>
+			</Site></SiteResponse>`, siteID),
+		},
+		RequestHandler{
+			PathAndQuery:     sitePath,
+			Method:           http.MethodPut,
+			EstimatedContent: `<Site><Name>site1</Name><Address><City>Cary</City><StateCode>NC</StateCode><AddressType>Service</AddressType></Address></Site>`,
+		},
+		RequestHandler{
+			PathAndQuery:  sitePath + "/sippeers",
+			ContentToSend: `<TNSipPeersResponse><SipPeers></SipPeers></TNSipPeersResponse>`,
+		},
+	})
+	defer server.Close()
+	spec := &InfrastructureSpec{Sites: []SiteSpec{SiteSpec{Name: "site1", Address: &AddressSpec{City: "Cary"}}}}
+	plan, err := api.Reconcile(context.Background(), spec, false)
+	if err != nil {
+		t.Fatalf("Failed call of Reconcile(): %v", err)
+	}
+	expect(t, plan.String(), "update-site site1: update site")
+}
+
+func TestReconcile(t *testing.T) {
+	siteID := "12345"
+	peerID := "678"
+	server, api := startMockServer(t, []RequestHandler{
+		RequestHandler{
+			PathAndQuery:  fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+			ContentToSend: `<SitesResponse><Sites></Sites></SitesResponse>`,
+		},
+		RequestHandler{
+			PathAndQuery:     fmt.Sprintf("%s%s/sites", accountsPath, testAccountID),
+			Method:           http.MethodPost,
+			EstimatedContent: `<Site><Name>site1</Name></Site>`,
+			HeadersToSend: map[string]string{
+				"Location": fmt.Sprintf("https://dashboard.bandwidth.com:443/v1.0/accounts/%s/sites/%s", testAccountID, siteID),
+			},
+		},
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers", accountsPath, testAccountID, siteID),
+			Method:       http.MethodPost,
+			HeadersToSend: map[string]string{
+				"Location": fmt.Sprintf("https://dashboard.bandwidth.com:443/v1.0/accounts/%s/sites/%s/sippeers/%s", testAccountID, siteID, peerID),
+			},
+		},
+		RequestHandler{
+			PathAndQuery: fmt.Sprintf("%s%s/sites/%s/sippeers/%s/products/messaging/features/mms", accountsPath, testAccountID, siteID, peerID),
+			Method:       http.MethodPost,
+		},
+	})
+	defer server.Close()
+	spec := &InfrastructureSpec{Sites: []SiteSpec{SiteSpec{Name: "site1", Peers: []PeerSpec{PeerSpec{Name: "peer1", MMS: true}}}}}
+	plan, err := api.Reconcile(context.Background(), spec, true)
+	if err != nil {
+		t.Fatalf("Failed call of Reconcile(): %v", err)
+	}
+	expect(t, len(plan.Actions), 3)
+	plan, err = api.Reconcile(context.Background(), spec, false)
+	if err != nil {
+		t.Fatalf("Failed call of Reconcile(): %v", err)
+	}
+	expect(t, plan.String(), "create-site site1: create site\ncreate-peer site1/peer1: create sip-peer\nenable-mms site1/peer1: enable MMS")
+}
@@ -0,0 +1,130 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests.
+// Requests are retried on 429, 5xx and connection resets.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry (default 500ms).
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff (default 30s).
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows retrying POST requests (like orders or messages) and other
+	// requests creating a resource (like order notes), which may cause them to be executed twice.
+	RetryNonIdempotent bool
+}
+
+var (
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+type nonIdempotentKey struct{}
+
+// withNonIdempotent marks the request as not safe to repeat even if its method is (like a PUT adding a note).
+func withNonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentKey{}, true)
+}
+
+func (p *RetryPolicy) canRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(ctx, method) {
+		return false
+	}
+	return isRetryableError(err)
+}
+
+func isIdempotent(ctx context.Context, method string) bool {
+	if ctx.Value(nonIdempotentKey{}) != nil {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func isRetryableError(err error) bool {
+	var rateLimitError *RateLimitError
+	if errors.As(err, &rateLimitError) {
+		return true
+	}
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode >= 500
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay before the given retry (attempt starts from 1).
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var rateLimitError *RateLimitError
+	if errors.As(err, &rateLimitError) {
+		if delay := time.Until(rateLimitError.Reset); delay > 0 {
+			return delay
+		}
+	}
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.retryAfter > 0 {
+		return apiError.retryAfter
+	}
+	minBackoff := p.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+	delay := minBackoff
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	// equal jitter: keep a half of the delay, randomize the rest
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses Retry-After header (in seconds or as HTTP date).
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
@@ -0,0 +1,149 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func startFlakyServer(t *testing.T, failures int, statusCode int, policy *RetryPolicy) (*httptest.Server, *Client, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method == http.MethodPost {
+			expect(t, readText(t, r.Body), `{"from":"fromNumber"}`)
+		}
+		if calls <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statusCode)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "1"}`))
+	}))
+	api := getAPI(server.URL)
+	api.verbose = false
+	api.retryPolicy = policy
+	return server, api, &calls
+}
+
+func TestRetry(t *testing.T) {
+	server, api, calls := startFlakyServer(t, 2, http.StatusServiceUnavailable, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	defer server.Close()
+	result, _, err := api.makeMessagingRequest(context.Background(), http.MethodGet, api.MessagingEndpoint, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, result.(map[string]interface{})["id"], "1")
+	expect(t, *calls, 3)
+}
+
+func TestRetryRateLimit(t *testing.T) {
+	server, api, calls := startFlakyServer(t, 1, http.StatusTooManyRequests, &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+	defer server.Close()
+	_, _, err := api.makeMessagingRequest(context.Background(), http.MethodGet, api.MessagingEndpoint, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, *calls, 2)
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	server, api, calls := startFlakyServer(t, 5, http.StatusBadGateway, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	defer server.Close()
+	_, _, err := api.makeMessagingRequest(context.Background(), http.MethodGet, api.MessagingEndpoint, map[string]interface{}{})
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Expected *APIError - Got %v", err)
+	}
+	expect(t, apiError.StatusCode, http.StatusBadGateway)
+	expect(t, *calls, 3)
+}
+
+func TestRetryClientError(t *testing.T) {
+	server, api, calls := startFlakyServer(t, 1, http.StatusBadRequest, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	defer server.Close()
+	_, _, err := api.makeMessagingRequest(context.Background(), http.MethodGet, api.MessagingEndpoint, map[string]interface{}{})
+	if err == nil {
+		t.Fatal("Should fail here")
+	}
+	expect(t, *calls, 1)
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	server, api, calls := startFlakyServer(t, 1, http.StatusServiceUnavailable, &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	defer server.Close()
+	data := map[string]string{"from": "fromNumber"}
+	_, _, err := api.makeMessagingRequest(context.Background(), http.MethodPost, api.MessagingEndpoint, nil, data)
+	if err == nil {
+		t.Fatal("Should fail here")
+	}
+	expect(t, *calls, 1)
+
+	api.retryPolicy.RetryNonIdempotent = true
+	*calls = 0
+	_, _, err = api.makeMessagingRequest(context.Background(), http.MethodPost, api.MessagingEndpoint, nil, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, *calls, 2)
+}
+
+func TestRetryAddOrderNote(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Location", r.URL.Path+"/11")
+	}))
+	defer server.Close()
+	api := getAPI(server.URL)
+	api.retryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	_, err := api.AddOrderNote(context.Background(), "1-2-3-4", "user", "note")
+	if err == nil {
+		t.Fatal("Should fail here")
+	}
+	expect(t, calls, 1)
+
+	api.retryPolicy.RetryNonIdempotent = true
+	calls = 0
+	id, err := api.AddOrderNote(context.Background(), "1-2-3-4", "user", "note")
+	expectNil(t, err)
+	expect(t, id, "11")
+	expect(t, calls, 2)
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	server, api, calls := startFlakyServer(t, 5, http.StatusServiceUnavailable, &RetryPolicy{MaxAttempts: 5, MinBackoff: time.Hour})
+	defer server.Close()
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := api.makeMessagingRequest(ctx, http.MethodGet, api.MessagingEndpoint, nil)
+	expect(t, err, context.DeadlineExceeded)
+	expect(t, *calls, 1)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		delay := policy.backoff(attempt+1, errors.New("error"))
+		if delay < max/2 || delay > max {
+			t.Errorf("Unexpected delay %v for attempt %d", delay, attempt+1)
+		}
+	}
+	reset := time.Now().Add(time.Minute)
+	delay := policy.backoff(1, &RateLimitError{Reset: reset})
+	if delay < 59*time.Second {
+		t.Errorf("Rate limit reset is not respected: %v", delay)
+	}
+}
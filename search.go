@@ -0,0 +1,161 @@
+package bandwidth
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// NumberSearch builds the query of the available numbers search.
+// Combine one search type (like AreaCode or Zip) with the common options (like Quantity).
+type NumberSearch struct {
+	params     map[string]string
+	sequential int
+}
+
+// NewNumberSearch creates an empty search.
+func NewNumberSearch() *NumberSearch {
+	return &NumberSearch{params: make(map[string]string)}
+}
+
+func (s *NumberSearch) set(key, value string) *NumberSearch {
+	s.params[key] = value
+	return s
+}
+
+// AreaCode searches numbers in the area code (NPA).
+func (s *NumberSearch) AreaCode(areaCode string) *NumberSearch {
+	return s.set("areaCode", areaCode)
+}
+
+// NpaNxx searches numbers in the 6 digit NPA-NXX or the 7 digit NPA-NXX-X.
+func (s *NumberSearch) NpaNxx(npaNxx string) *NumberSearch {
+	if len(npaNxx) == 7 {
+		return s.set("npaNxxx", npaNxx)
+	}
+	return s.set("npaNxx", npaNxx)
+}
+
+// City searches numbers in the city of the state.
+func (s *NumberSearch) City(city, state string) *NumberSearch {
+	return s.set("city", city).set("state", state)
+}
+
+// State searches numbers in the state (like NC).
+func (s *NumberSearch) State(state string) *NumberSearch {
+	return s.set("state", state)
+}
+
+// Zip searches numbers in the ZIP code.
+func (s *NumberSearch) Zip(zip string) *NumberSearch {
+	return s.set("zip", zip)
+}
+
+// RateCenter searches numbers in the rate center of the state.
+func (s *NumberSearch) RateCenter(rateCenter, state string) *NumberSearch {
+	return s.set("rateCenter", rateCenter).set("state", state)
+}
+
+// Lata searches numbers in the LATA.
+func (s *NumberSearch) Lata(lata string) *NumberSearch {
+	return s.set("lata", lata)
+}
+
+// LocalVanity searches local numbers containing the vanity pattern (like NEWCARS).
+func (s *NumberSearch) LocalVanity(vanity string) *NumberSearch {
+	return s.set("localVanity", vanity)
+}
+
+// TollFreeVanity searches toll-free numbers containing the vanity pattern.
+func (s *NumberSearch) TollFreeVanity(vanity string) *NumberSearch {
+	return s.set("tollFreeVanity", vanity)
+}
+
+// TollFreeWildCard searches toll-free numbers matching the mask (like 8**).
+func (s *NumberSearch) TollFreeWildCard(mask string) *NumberSearch {
+	return s.set("tollFreeWildCardPattern", mask)
+}
+
+// Quantity sets the maximum number of found numbers.
+func (s *NumberSearch) Quantity(n int) *NumberSearch {
+	return s.set("quantity", strconv.Itoa(n))
+}
+
+// WithDetails requests the details (city, rate center, LATA, vendor) of the found numbers.
+func (s *NumberSearch) WithDetails() *NumberSearch {
+	return s.set("enableTNDetail", "true")
+}
+
+// LCA includes the numbers of the local calling area.
+func (s *NumberSearch) LCA(enabled bool) *NumberSearch {
+	return s.set("LCA", strconv.FormatBool(enabled))
+}
+
+// Sequential keeps only the numbers which are part of a block of at least n consecutive numbers.
+// The filtering is done on the found numbers, so request a bigger Quantity.
+func (s *NumberSearch) Sequential(n int) *NumberSearch {
+	s.sequential = n
+	return s
+}
+
+// SearchNumbers finds the available numbers.
+func (c *Client) SearchNumbers(ctx context.Context, search *NumberSearch) (*SearchResult, error) {
+	path := c.AccountsEndpoint + "/availableNumbers"
+	result, _, err := c.makeAccountsRequest(ctx, http.MethodGet, path, &SearchResult{}, search.params)
+	if err != nil {
+		return nil, err
+	}
+	searchResult := result.(*SearchResult)
+	if search.sequential > 1 {
+		filterSequential(searchResult, search.sequential)
+	}
+	return searchResult, nil
+}
+
+// filterSequential removes the numbers which are not in a block of n consecutive numbers.
+func filterSequential(result *SearchResult, n int) {
+	numbers := result.TelephoneNumberList.TelephoneNumber
+	for _, detail := range result.TelephoneNumberDetailList.TelephoneNumberDetail {
+		numbers = append(numbers, detail.FullNumber)
+	}
+	present := make(map[int64]bool, len(numbers))
+	for _, number := range numbers {
+		if value, err := strconv.ParseInt(number, 10, 64); err == nil {
+			present[value] = true
+		}
+	}
+	keep := make(map[string]bool)
+	for _, number := range numbers {
+		value, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			continue
+		}
+		// find the bounds of the block containing the number
+		start := value
+		for present[start-1] {
+			start--
+		}
+		end := value
+		for present[end+1] {
+			end++
+		}
+		if end-start+1 >= int64(n) {
+			keep[number] = true
+		}
+	}
+	var list []string
+	for _, number := range result.TelephoneNumberList.TelephoneNumber {
+		if keep[number] {
+			list = append(list, number)
+		}
+	}
+	var details []TelephoneNumberDetail
+	for _, detail := range result.TelephoneNumberDetailList.TelephoneNumberDetail {
+		if keep[detail.FullNumber] {
+			details = append(details, detail)
+		}
+	}
+	result.TelephoneNumberList.TelephoneNumber = list
+	result.TelephoneNumberDetailList.TelephoneNumberDetail = details
+	result.ResultCount = len(list) + len(details)
+}
@@ -0,0 +1,94 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSearchNumbers(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/availableNumbers?LCA=false&city=KNIGHTDALE&enableTNDetail=true&quantity=2&state=NC", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<SearchResult>
+			<ResultCount>2</ResultCount>
+			<TelephoneNumberDetailList>
+				<TelephoneNumberDetail>
+					<City>KNIGHTDALE</City>
+					<LATA>426</LATA>
+					<RateCenter>KNIGHTDALE</RateCenter>
+					<State>NC</State>
+					<FullNumber>9192956932</FullNumber>
+					<Tier>0</Tier>
+					<VendorId>49</VendorId>
+					<VendorName>Bandwidth CLEC</VendorName>
+				</TelephoneNumberDetail>
+				<TelephoneNumberDetail>
+					<City>KNIGHTDALE</City>
+					<LATA>426</LATA>
+					<RateCenter>KNIGHTDALE</RateCenter>
+					<State>NC</State>
+					<FullNumber>9192956933</FullNumber>
+					<Tier>0</Tier>
+					<VendorId>49</VendorId>
+					<VendorName>Bandwidth CLEC</VendorName>
+				</TelephoneNumberDetail>
+			</TelephoneNumberDetailList>
+		</SearchResult>`}})
+	defer server.Close()
+	result, err := api.SearchNumbers(context.Background(), NewNumberSearch().City("KNIGHTDALE", "NC").Quantity(2).WithDetails().LCA(false))
+	if err != nil {
+		t.Errorf("Failed call of SearchNumbers(): %v", err)
+		return
+	}
+	expect(t, result.ResultCount, 2)
+	expect(t, result.TelephoneNumberDetailList.TelephoneNumberDetail[0], TelephoneNumberDetail{
+		FullNumber: "9192956932",
+		City:       "KNIGHTDALE",
+		RateCenter: "KNIGHTDALE",
+		State:      "NC",
+		LATA:       "426",
+		Tier:       "0",
+		VendorID:   "49",
+		VendorName: "Bandwidth CLEC",
+	})
+}
+
+func TestSearchNumbersQuery(t *testing.T) {
+	expect(t, NewNumberSearch().NpaNxx("919295").params, map[string]string{"npaNxx": "919295"})
+	expect(t, NewNumberSearch().NpaNxx("9192956").params, map[string]string{"npaNxxx": "9192956"})
+	expect(t, NewNumberSearch().RateCenter("RALEIGH", "NC").params, map[string]string{"rateCenter": "RALEIGH", "state": "NC"})
+	expect(t, NewNumberSearch().Zip("27606").Lata("426").params, map[string]string{"zip": "27606", "lata": "426"})
+	expect(t, NewNumberSearch().LocalVanity("NEWCARS").params, map[string]string{"localVanity": "NEWCARS"})
+	expect(t, NewNumberSearch().TollFreeVanity("NEWCARS").params, map[string]string{"tollFreeVanity": "NEWCARS"})
+	expect(t, NewNumberSearch().TollFreeWildCard("8**").params, map[string]string{"tollFreeWildCardPattern": "8**"})
+}
+
+func TestSearchNumbersSequential(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: fmt.Sprintf("%s%s/availableNumbers?areaCode=919&quantity=10", accountsPath, testAccountID),
+		Method:       http.MethodGet,
+		ContentToSend: `
+		<SearchResult>
+			<ResultCount>6</ResultCount>
+			<TelephoneNumberList>
+				<TelephoneNumber>9192956932</TelephoneNumber>
+				<TelephoneNumber>9192956940</TelephoneNumber>
+				<TelephoneNumber>9192956934</TelephoneNumber>
+				<TelephoneNumber>9192956933</TelephoneNumber>
+				<TelephoneNumber>9192956941</TelephoneNumber>
+				<TelephoneNumber>9192956950</TelephoneNumber>
+			</TelephoneNumberList>
+		</SearchResult>`}})
+	defer server.Close()
+	result, err := api.SearchNumbers(context.Background(), NewNumberSearch().AreaCode("919").Quantity(10).Sequential(3))
+	if err != nil {
+		t.Errorf("Failed call of SearchNumbers(): %v", err)
+		return
+	}
+	expect(t, result.ResultCount, 3)
+	expect(t, result.TelephoneNumberList.TelephoneNumber, []string{"9192956932", "9192956934", "9192956933"})
+}
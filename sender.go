@@ -0,0 +1,243 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NumberClass is the class of the number messages are sent from.
+// Carriers allow different throughput for every class.
+type NumberClass int
+
+const (
+	// LongCode is a regular 10 digit number.
+	LongCode NumberClass = iota
+	// TollFree is a toll-free number (8XX).
+	TollFree
+	// ShortCode is a 5-6 digit short code.
+	ShortCode
+)
+
+var (
+	defaultLongCodeRate  = RateLimit{Rate: 1, Burst: 1}
+	defaultTollFreeRate  = RateLimit{Rate: 25, Burst: 1}
+	defaultShortCodeRate = RateLimit{Rate: 100, Burst: 1}
+	defaultSenderQueue   = 1000
+	defaultSenderIdle    = time.Minute
+	tollFreePrefixes     = []string{"800", "833", "844", "855", "866", "877", "888"}
+)
+
+// ErrSenderClosed is returned by Sender.Send after Close has been called.
+var ErrSenderClosed = errors.New("sender is closed")
+
+// SenderOpts are the options to create the Sender.
+type SenderOpts struct {
+	// Rates per number class. Zero values use the defaults:
+	// 1 message/second for long codes, 25 for toll-free numbers and 100 for short codes.
+	LongCodeRate, TollFreeRate, ShortCodeRate RateLimit
+	// Classify returns the class of the number. By default it is detected by the number format.
+	Classify func(from string) NumberClass
+	// QueueSize is the number of messages queued per source number before Send blocks (default 1000).
+	QueueSize int
+	// IdleTimeout is how long the queue of a source number is kept without messages (default 1 minute).
+	// It should be longer than the interval between messages allowed by the rates.
+	IdleTimeout time.Duration
+}
+
+// MessageResult is the result of the message queued by Sender.
+type MessageResult struct {
+	Message *CreateMessageResponse
+	Err     error
+}
+
+// PendingMessage is a message queued by Sender. Its result is available once Done is closed.
+type PendingMessage struct {
+	done   chan struct{}
+	result MessageResult
+}
+
+// Done returns a channel which is closed when the message has been sent or failed.
+func (p *PendingMessage) Done() <-chan struct{} {
+	return p.done
+}
+
+// Result returns the result of the message. It blocks until the message is processed.
+func (p *PendingMessage) Result() MessageResult {
+	<-p.done
+	return p.result
+}
+
+// Wait waits for the message to be processed or for the context to be done.
+func (p *PendingMessage) Wait(ctx context.Context) (*CreateMessageResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.done:
+		return p.result.Message, p.result.Err
+	}
+}
+
+// senderQueue is the queue of a source number.
+type senderQueue struct {
+	messages chan *queuedMessage
+	// pending is the number of Send calls using the queue (guarded by Sender.mu).
+	pending int
+}
+
+type queuedMessage struct {
+	ctx     context.Context
+	data    *CreateMessage
+	pending *PendingMessage
+}
+
+// Sender sends messages via CreateMessage, queuing them per source number
+// so the throughput of every number stays within its carrier limits.
+type Sender struct {
+	client  *Client
+	opts    SenderOpts
+	mu      sync.Mutex
+	closed  bool
+	queues  map[string]*senderQueue
+	sending sync.WaitGroup
+	workers sync.WaitGroup
+}
+
+// NewSender creates new Sender using the client.
+func (c *Client) NewSender(opts SenderOpts) *Sender {
+	if opts.LongCodeRate.Rate <= 0 {
+		opts.LongCodeRate = defaultLongCodeRate
+	}
+	if opts.TollFreeRate.Rate <= 0 {
+		opts.TollFreeRate = defaultTollFreeRate
+	}
+	if opts.ShortCodeRate.Rate <= 0 {
+		opts.ShortCodeRate = defaultShortCodeRate
+	}
+	if opts.Classify == nil {
+		opts.Classify = ClassifyNumber
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultSenderQueue
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = defaultSenderIdle
+	}
+	return &Sender{client: c, opts: opts, queues: make(map[string]*senderQueue)}
+}
+
+// ClassifyNumber detects the class of the number by its format.
+func ClassifyNumber(number string) NumberClass {
+	digits := strings.TrimPrefix(number, "+")
+	if len(digits) <= 6 {
+		return ShortCode
+	}
+	digits = strings.TrimPrefix(digits, "1")
+	for _, prefix := range tollFreePrefixes {
+		if strings.HasPrefix(digits, prefix) {
+			return TollFree
+		}
+	}
+	return LongCode
+}
+
+// Send queues the message. It blocks only when the queue of the source number is full.
+// The context is used for queuing and sending the message.
+func (s *Sender) Send(ctx context.Context, data *CreateMessage) (*PendingMessage, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, ErrSenderClosed
+	}
+	queue, ok := s.queues[data.From]
+	if !ok {
+		queue = &senderQueue{messages: make(chan *queuedMessage, s.opts.QueueSize)}
+		s.queues[data.From] = queue
+		s.workers.Add(1)
+		go s.run(data.From, queue)
+	}
+	queue.pending++
+	s.sending.Add(1)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		queue.pending--
+		s.mu.Unlock()
+		s.sending.Done()
+	}()
+
+	pending := &PendingMessage{done: make(chan struct{})}
+	select {
+	case queue.messages <- &queuedMessage{ctx: ctx, data: data, pending: pending}:
+		return pending, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops accepting new messages and waits until all queued messages are processed.
+func (s *Sender) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+	s.sending.Wait()
+	for _, queue := range s.queues {
+		close(queue.messages)
+	}
+	s.workers.Wait()
+}
+
+func (s *Sender) run(from string, queue *senderQueue) {
+	defer s.workers.Done()
+	var limit RateLimit
+	switch s.opts.Classify(from) {
+	case TollFree:
+		limit = s.opts.TollFreeRate
+	case ShortCode:
+		limit = s.opts.ShortCodeRate
+	default:
+		limit = s.opts.LongCodeRate
+	}
+	bucket := newTokenBucket(&limit)
+	idle := time.NewTimer(s.opts.IdleTimeout)
+	defer idle.Stop()
+	for {
+		select {
+		case message, ok := <-queue.messages:
+			if !ok {
+				return
+			}
+			if err := bucket.Wait(message.ctx); err != nil {
+				message.pending.result.Err = err
+			} else {
+				message.pending.result.Message, message.pending.result.Err = s.client.CreateMessage(message.ctx, message.data)
+			}
+			close(message.pending.done)
+			if !idle.Stop() {
+				<-idle.C
+			}
+		case <-idle.C:
+			if s.removeIdle(from, queue) {
+				return
+			}
+		}
+		idle.Reset(s.opts.IdleTimeout)
+	}
+}
+
+// removeIdle removes the queue if it is empty and no Send is using it.
+func (s *Sender) removeIdle(from string, queue *senderQueue) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || queue.pending > 0 || len(queue.messages) > 0 {
+		return false
+	}
+	delete(s.queues, from)
+	return true
+}
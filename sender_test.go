@@ -0,0 +1,116 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClassifyNumber(t *testing.T) {
+	expect(t, ClassifyNumber("+19195551234"), LongCode)
+	expect(t, ClassifyNumber("9195551234"), LongCode)
+	expect(t, ClassifyNumber("+18445551234"), TollFree)
+	expect(t, ClassifyNumber("8885551234"), TollFree)
+	expect(t, ClassifyNumber("12345"), ShortCode)
+	expect(t, ClassifyNumber("123456"), ShortCode)
+}
+
+func TestSender(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  fmt.Sprintf("/api/v2/users/%s/messages", testAccountID),
+		Method:        http.MethodPost,
+		ContentToSend: `{"id": "1", "from": "+19195551234"}`}})
+	defer server.Close()
+	api.verbose = false
+	sender := api.NewSender(SenderOpts{LongCodeRate: RateLimit{Rate: 20}})
+	start := time.Now()
+	var pending []*PendingMessage
+	for i := 0; i < 3; i++ {
+		p, err := sender.Send(context.Background(), &CreateMessage{From: "+19195551234", To: "+19195554321", Text: "text"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pending = append(pending, p)
+	}
+	other, err := sender.Send(context.Background(), &CreateMessage{From: "+18445551234", To: "+19195554321", Text: "text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := other.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, message.ID, "1")
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Messages from another number should not be delayed: %v", elapsed)
+	}
+	sender.Close()
+	for _, p := range pending {
+		result := p.Result()
+		expectNil(t, result.Err)
+		expect(t, result.Message.From, "+19195551234")
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Messages are not shaped: %v", elapsed)
+	}
+	_, err = sender.Send(context.Background(), &CreateMessage{From: "+19195551234"})
+	expect(t, err, ErrSenderClosed)
+}
+
+func TestSenderFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     fmt.Sprintf("/api/v2/users/%s/messages", testAccountID),
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusBadRequest,
+		ContentToSend:    `{"type": "request-validation", "description": "Invalid from"}`}})
+	defer server.Close()
+	sender := api.NewSender(SenderOpts{})
+	defer sender.Close()
+	p, err := sender.Send(context.Background(), &CreateMessage{From: "invalid", To: "+19195554321", Text: "text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = p.Wait(context.Background())
+	expect(t, err.(*APIError).Description, "Invalid from")
+}
+
+func TestSenderRemovesIdleQueues(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  fmt.Sprintf("/api/v2/users/%s/messages", testAccountID),
+		Method:        http.MethodPost,
+		ContentToSend: `{"id": "1", "from": "+19195551234"}`}})
+	defer server.Close()
+	api.verbose = false
+	sender := api.NewSender(SenderOpts{IdleTimeout: 10 * time.Millisecond})
+	defer sender.Close()
+	queues := func() int {
+		sender.mu.Lock()
+		defer sender.mu.Unlock()
+		return len(sender.queues)
+	}
+	for _, from := range []string{"+19195551234", "+19195551235"} {
+		p, err := sender.Send(context.Background(), &CreateMessage{From: from, To: "+19195554321", Text: "text"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect(t, queues(), 2)
+	deadline := time.Now().Add(time.Second)
+	for queues() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	expect(t, queues(), 0)
+	// the number gets a new queue when it sends again
+	p, err := sender.Send(context.Background(), &CreateMessage{From: "+19195551234", To: "+19195554321", Text: "text"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := p.Wait(context.Background())
+	expectNil(t, err)
+	expect(t, message.ID, "1")
+}
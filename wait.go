@@ -0,0 +1,176 @@
+package bandwidth
+
+import (
+	"context"
+	"time"
+)
+
+// Statuses of the orders.
+const (
+	OrderStatusReceived    = "RECEIVED"
+	OrderStatusBackordered = "BACKORDERED"
+	OrderStatusProcessing  = "PROCESSING"
+	OrderStatusComplete    = "COMPLETE"
+	OrderStatusPartial     = "PARTIAL"
+	OrderStatusFailed      = "FAILED"
+)
+
+var (
+	defaultWaitInterval    = time.Second
+	defaultMaxWaitInterval = 30 * time.Second
+)
+
+// WaitOpts are the options of the order waiters.
+type WaitOpts struct {
+	// Interval is the delay before the first poll (default 1s). It doubles after every poll.
+	Interval time.Duration
+	// MaxInterval caps the delay between polls (default 30s).
+	MaxInterval time.Duration
+	// Progress is called with the status of the order after every poll.
+	Progress func(status string)
+}
+
+// OrderResult is the final state of a number order.
+type OrderResult struct {
+	// Status is COMPLETE, PARTIAL or FAILED.
+	Status string
+	// CompletedNumbers are the numbers which have been ordered.
+	CompletedNumbers []string
+	// FailedNumbers are the numbers which could not be ordered.
+	FailedNumbers []string
+	// Errors contains the reasons of the failures (per number if available).
+	Errors []ErrorDetail
+	// Order is the last polled state of the order.
+	Order *OrderResponse
+}
+
+// DisconnectResult is the final state of a disconnect order.
+type DisconnectResult struct {
+	// Status is COMPLETE, PARTIAL or FAILED.
+	Status string
+	// DisconnectedNumbers are the numbers which have been disconnected.
+	DisconnectedNumbers []string
+	// FailedNumbers are the numbers which could not be disconnected.
+	FailedNumbers []string
+	// Errors contains the reasons of the failures (per number if available).
+	Errors []ErrorDetail
+	// Disconnect is the last polled state of the disconnect order.
+	Disconnect *DisconnectTelephoneNumberOrderResponse
+}
+
+func isFinalOrderStatus(status string) bool {
+	return status == OrderStatusComplete || status == OrderStatusPartial || status == OrderStatusFailed
+}
+
+// poll calls check until it reports the final state, waiting between the calls with exponential backoff.
+func poll(ctx context.Context, opts WaitOpts, check func() (status string, done bool, err error)) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultMaxWaitInterval
+	}
+	for {
+		status, done, err := check()
+		if err != nil {
+			return err
+		}
+		if opts.Progress != nil {
+			opts.Progress(status)
+		}
+		if done {
+			return nil
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// WaitForOrder polls the order until it is COMPLETE, PARTIAL or FAILED.
+// A FAILED order is not an error, check Status of the result.
+func (c *Client) WaitForOrder(ctx context.Context, id string, opts WaitOpts) (*OrderResult, error) {
+	var order *OrderResponse
+	err := poll(ctx, opts, func() (string, bool, error) {
+		var err error
+		order, err = c.GetOrder(ctx, id)
+		if err != nil {
+			return "", false, err
+		}
+		return order.OrderStatus, isFinalOrderStatus(order.OrderStatus), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &OrderResult{
+		Status:        order.OrderStatus,
+		FailedNumbers: order.FailedNumbers.FullNumber,
+		Errors:        order.ErrorList,
+		Order:         order,
+	}
+	for _, number := range order.CompletedNumbers.TelephoneNumbers {
+		result.CompletedNumbers = append(result.CompletedNumbers, number.FullNumber)
+	}
+	return result, nil
+}
+
+// WaitForDisconnect polls the disconnect order until it is COMPLETE, PARTIAL or FAILED.
+// A FAILED disconnect is not an error, check Status of the result.
+func (c *Client) WaitForDisconnect(ctx context.Context, id string, opts WaitOpts) (*DisconnectResult, error) {
+	var disconnect *DisconnectTelephoneNumberOrderResponse
+	err := poll(ctx, opts, func() (string, bool, error) {
+		var err error
+		disconnect, err = c.GetDisconnect(ctx, id)
+		if err != nil {
+			return "", false, err
+		}
+		return disconnect.OrderStatus, isFinalOrderStatus(disconnect.OrderStatus), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &DisconnectResult{
+		Status:     disconnect.OrderStatus,
+		Errors:     disconnect.ErrorList,
+		Disconnect: disconnect,
+	}
+	failed := make(map[string]bool)
+	for _, e := range disconnect.ErrorList {
+		if e.TelephoneNumber != "" {
+			failed[e.TelephoneNumber] = true
+		}
+	}
+	for _, number := range disconnect.OrderRequest.DisconnectTelephoneNumberOrderType.TelephoneNumberList.TelephoneNumber {
+		// without per-number errors a failed order means none of the numbers was disconnected
+		if failed[number] || (len(failed) == 0 && disconnect.OrderStatus == OrderStatusFailed) {
+			result.FailedNumbers = append(result.FailedNumbers, number)
+		} else {
+			result.DisconnectedNumbers = append(result.DisconnectedNumbers, number)
+		}
+	}
+	return result, nil
+}
+
+// WaitForTnOptionOrder polls the TN option order until it is COMPLETE, PARTIAL or FAILED.
+// A FAILED order is not an error, check ProcessingStatus and ErrorList of the result.
+func (c *Client) WaitForTnOptionOrder(ctx context.Context, id string, opts WaitOpts) (*TnOptionOrderDetails, error) {
+	var order *TnOptionOrderDetails
+	err := poll(ctx, opts, func() (string, bool, error) {
+		var err error
+		order, err = c.GetTnOptionOrder(ctx, id)
+		if err != nil {
+			return "", false, err
+		}
+		return order.ProcessingStatus, isFinalOrderStatus(order.ProcessingStatus), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
@@ -0,0 +1,175 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// startSequenceServer responds to the path with the given XML bodies, one per request.
+// The last body is repeated.
+func startSequenceServer(t *testing.T, path string, bodies []string) (*httptest.Server, *Client) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := bodies[len(bodies)-1]
+		if calls < len(bodies) {
+			body = bodies[calls]
+		}
+		calls++
+		fmt.Fprint(w, body)
+	}))
+	api := getAPI(server.URL)
+	api.verbose = false
+	return server, api
+}
+
+func TestWaitForOrder(t *testing.T) {
+	id := "1-2-3-4"
+	server, api := startSequenceServer(t, fmt.Sprintf("%s%s/orders/%s", accountsPath, testAccountID, id), []string{
+		`<OrderResponse><OrderStatus>RECEIVED</OrderStatus></OrderResponse>`,
+		`<OrderResponse><OrderStatus>RECEIVED</OrderStatus></OrderResponse>`,
+		`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<OrderResponse>
+			<CompletedQuantity>1</CompletedQuantity>
+			<OrderStatus>PARTIAL</OrderStatus>
+			<CompletedNumbers>
+				<TelephoneNumber>
+					<FullNumber>7341231234</FullNumber>
+				</TelephoneNumber>
+			</CompletedNumbers>
+			<FailedNumbers>
+				<FullNumber>7341232222</FullNumber>
+			</FailedNumbers>
+			<FailedQuantity>1</FailedQuantity>
+			<ErrorList>
+				<Error>
+					<Code>5005</Code>
+					<Description>The telephone number is unavailable for ordering</Description>
+					<TelephoneNumber>7341232222</TelephoneNumber>
+				</Error>
+			</ErrorList>
+		</OrderResponse>`,
+	})
+	defer server.Close()
+	var statuses []string
+	result, err := api.WaitForOrder(context.Background(), id, WaitOpts{Interval: time.Millisecond, Progress: func(status string) {
+		statuses = append(statuses, status)
+	}})
+	if err != nil {
+		t.Fatalf("Failed call of WaitForOrder(): %v", err)
+	}
+	expect(t, statuses, []string{"RECEIVED", "RECEIVED", "PARTIAL"})
+	expect(t, result.Status, OrderStatusPartial)
+	expect(t, result.CompletedNumbers, []string{"7341231234"})
+	expect(t, result.FailedNumbers, []string{"7341232222"})
+	expect(t, result.Errors[0].Code, "5005")
+	expect(t, result.Errors[0].TelephoneNumber, "7341232222")
+	expect(t, result.Order.FailedQuantity, 1)
+}
+
+func TestWaitForOrderContextCancelled(t *testing.T) {
+	id := "1-2-3-4"
+	server, api := startSequenceServer(t, fmt.Sprintf("%s%s/orders/%s", accountsPath, testAccountID, id), []string{
+		`<OrderResponse><OrderStatus>RECEIVED</OrderStatus></OrderResponse>`,
+	})
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := api.WaitForOrder(ctx, id, WaitOpts{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded - Got %v", err)
+	}
+}
+
+func TestWaitForDisconnect(t *testing.T) {
+	id := "1-2-3-4"
+	server, api := startSequenceServer(t, fmt.Sprintf("%s%s/disconnects/%s", accountsPath, testAccountID, id), []string{
+		`<DisconnectTelephoneNumberOrderResponse><OrderStatus>RECEIVED</OrderStatus></DisconnectTelephoneNumberOrderResponse>`,
+		`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+		<DisconnectTelephoneNumberOrderResponse>
+			<orderRequest>
+				<OrderCreateDate>2019-11-21T04:36:33.247Z</OrderCreateDate>
+				<id>1-2-3-4</id>
+				<DisconnectTelephoneNumberOrderType>
+					<TelephoneNumberList>
+						<TelephoneNumber>7341231234</TelephoneNumber>
+						<TelephoneNumber>7341232222</TelephoneNumber>
+					</TelephoneNumberList>
+				</DisconnectTelephoneNumberOrderType>
+			</orderRequest>
+			<ErrorList>
+				<Error>
+					<Code>5006</Code>
+					<Description>Telephone number could not be disconnected since it is not associated with your account</Description>
+					<TelephoneNumber>7341232222</TelephoneNumber>
+				</Error>
+			</ErrorList>
+			<OrderStatus>PARTIAL</OrderStatus>
+		</DisconnectTelephoneNumberOrderResponse>`,
+	})
+	defer server.Close()
+	var statuses []string
+	result, err := api.WaitForDisconnect(context.Background(), id, WaitOpts{Interval: time.Millisecond, Progress: func(status string) {
+		statuses = append(statuses, status)
+	}})
+	if err != nil {
+		t.Fatalf("Failed call of WaitForDisconnect(): %v", err)
+	}
+	expect(t, statuses, []string{"RECEIVED", "PARTIAL"})
+	expect(t, result.Status, OrderStatusPartial)
+	expect(t, result.DisconnectedNumbers, []string{"7341231234"})
+	expect(t, result.FailedNumbers, []string{"7341232222"})
+	expect(t, result.Errors[0].Code, "5006")
+}
+
+func TestWaitForDisconnectFailed(t *testing.T) {
+	id := "1-2-3-4"
+	server, api := startSequenceServer(t, fmt.Sprintf("%s%s/disconnects/%s", accountsPath, testAccountID, id), []string{
+		`<DisconnectTelephoneNumberOrderResponse>
+			<orderRequest>
+				<DisconnectTelephoneNumberOrderType>
+					<TelephoneNumberList>
+						<TelephoneNumber>7341231234</TelephoneNumber>
+					</TelephoneNumberList>
+				</DisconnectTelephoneNumberOrderType>
+			</orderRequest>
+			<OrderStatus>FAILED</OrderStatus>
+		</DisconnectTelephoneNumberOrderResponse>`,
+	})
+	defer server.Close()
+	result, err := api.WaitForDisconnect(context.Background(), id, WaitOpts{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed call of WaitForDisconnect(): %v", err)
+	}
+	expect(t, result.Status, OrderStatusFailed)
+	expect(t, len(result.DisconnectedNumbers), 0)
+	expect(t, result.FailedNumbers, []string{"7341231234"})
+}
+
+func TestWaitForTnOptionOrder(t *testing.T) {
+	server, api := startSequenceServer(t, fmt.Sprintf("%s%s/tnoptions/1-2-3-4", accountsPath, testAccountID), []string{
+		`<TnOptionOrder><OrderId>1-2-3-4</OrderId><ProcessingStatus>RECEIVED</ProcessingStatus></TnOptionOrder>`,
+		`<TnOptionOrder><OrderId>1-2-3-4</OrderId><ProcessingStatus>PROCESSING</ProcessingStatus></TnOptionOrder>`,
+		`<TnOptionOrder><OrderId>1-2-3-4</OrderId><ProcessingStatus>COMPLETE</ProcessingStatus></TnOptionOrder>`,
+	})
+	defer server.Close()
+	var statuses []string
+	result, err := api.WaitForTnOptionOrder(context.Background(), "1-2-3-4", WaitOpts{
+		Interval: time.Millisecond,
+		Progress: func(status string) { statuses = append(statuses, status) },
+	})
+	if err != nil {
+		t.Fatalf("Failed call of WaitForTnOptionOrder(): %v", err)
+	}
+	expect(t, result.ProcessingStatus, OrderStatusComplete)
+	expect(t, statuses, []string{OrderStatusReceived, OrderStatusProcessing, OrderStatusComplete})
+}
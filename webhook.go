@@ -0,0 +1,275 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Types of the messaging callback events.
+const (
+	MessageReceived  = "message-received"
+	MessageDelivered = "message-delivered"
+	MessageFailed    = "message-failed"
+	MessageSending   = "message-sending"
+)
+
+// CallbackMessage is the message of a callback event.
+type CallbackMessage struct {
+	CreateMessageResponse
+	Owner string `json:"owner"`
+}
+
+// MessageEvent is a callback event sent by the messaging API.
+type MessageEvent struct {
+	Type        string          `json:"type"`
+	Time        *time.Time      `json:"time"`
+	Description string          `json:"description"`
+	To          string          `json:"to"`
+	ErrorCode   int             `json:"errorCode,omitempty"`
+	Message     CallbackMessage `json:"message"`
+}
+
+// MessageEventHandler handles a callback event. Returning an error makes the webhook respond
+// with 500 so the event will be delivered again.
+type MessageEventHandler func(ctx context.Context, event *MessageEvent) error
+
+// WebhookOpts are the options to create the WebhookHandler. All of them are optional.
+type WebhookOpts struct {
+	// UserName and Password are the callback credentials configured on the application.
+	// When set, requests without matching Basic-Auth credentials are rejected.
+	UserName, Password string
+	// AllowedNetworks is a list of IP addresses or CIDR networks allowed to post callbacks.
+	// Empty list allows any address.
+	AllowedNetworks []string
+	// TrustedProxies is a list of IP addresses or CIDR networks of the proxies in front of the webhook.
+	// X-Forwarded-For header is used only for requests coming from a trusted proxy: the client address
+	// is its rightmost entry which is not a trusted proxy (the entries left of it are set by the client).
+	TrustedProxies []string
+	// DuplicateWindow is how long the processed events are remembered. Events with the same type
+	// and message ID received within the window (or while the event is being handled) are acknowledged
+	// without being dispatched again.
+	DuplicateWindow time.Duration
+}
+
+// WebhookHandler is a http.Handler receiving the messaging callbacks
+// and dispatching them to the registered handlers by the event type.
+type WebhookHandler struct {
+	opts     WebhookOpts
+	networks []*net.IPNet
+	proxies  []*net.IPNet
+	mu       sync.RWMutex
+	handlers map[string]MessageEventHandler
+	seenMu   sync.Mutex
+	seen     map[string]time.Time
+	inFlight map[string]bool
+	pruned   time.Time
+}
+
+// NewWebhookHandler creates new instance of WebhookHandler.
+func NewWebhookHandler(opts WebhookOpts) (*WebhookHandler, error) {
+	if (opts.UserName == "") != (opts.Password == "") {
+		return nil, errors.New("both user name and password are required for callback authentication")
+	}
+	networks, err := parseNetworks(opts.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	proxies, err := parseNetworks(opts.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookHandler{opts: opts, networks: networks, proxies: proxies, handlers: make(map[string]MessageEventHandler),
+		seen: make(map[string]time.Time), inFlight: make(map[string]bool)}, nil
+}
+
+// parseNetworks parses the list of IP addresses or CIDR networks.
+func parseNetworks(addresses []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(addresses))
+	for _, network := range addresses {
+		if !strings.Contains(network, "/") {
+			if ip := net.ParseIP(network); ip != nil && ip.To4() != nil {
+				network += "/32"
+			} else {
+				network += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, ipNet)
+	}
+	return networks, nil
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle registers the handler for the event type (like MessageReceived).
+// Events without a registered handler are acknowledged and ignored.
+func (h *WebhookHandler) Handle(eventType string, handler MessageEventHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = handler
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.isAllowedAddress(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !h.isAuthorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="bandwidth"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	events, err := parseMessageEvents(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, event := range events {
+		h.mu.RLock()
+		handler := h.handlers[event.Type]
+		h.mu.RUnlock()
+		if handler == nil || !h.reserve(event) {
+			continue
+		}
+		err := handler(r.Context(), event)
+		h.release(event, err == nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseMessageEvents reads the events from the callback request.
+// Bandwidth sends an array of events but a single event is accepted too.
+func parseMessageEvents(r *http.Request) ([]*MessageEvent, error) {
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	body = bytes.TrimSpace(body)
+	var events []*MessageEvent
+	if len(body) > 0 && body[0] == '{' {
+		event := &MessageEvent{}
+		err = json.Unmarshal(body, event)
+		events = append(events, event)
+	} else {
+		err = json.Unmarshal(body, &events)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (h *WebhookHandler) isAuthorized(r *http.Request) bool {
+	if h.opts.UserName == "" {
+		return true
+	}
+	userName, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	userNameMatch := subtle.ConstantTimeCompare([]byte(userName), []byte(h.opts.UserName))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(h.opts.Password))
+	return userNameMatch&passwordMatch == 1
+}
+
+func (h *WebhookHandler) isAllowedAddress(r *http.Request) bool {
+	if len(h.networks) == 0 {
+		return true
+	}
+	address := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" && containsIP(h.proxies, ip) {
+		// every proxy appends the address it received the request from, so walk from the right
+		// past the trusted proxies
+		entries := strings.Split(forwarded, ",")
+		for i := len(entries) - 1; i >= 0; i-- {
+			if ip = net.ParseIP(strings.TrimSpace(entries[i])); ip == nil {
+				return false
+			}
+			if !containsIP(h.proxies, ip) {
+				break
+			}
+		}
+	}
+	return containsIP(h.networks, ip)
+}
+
+func eventKey(event *MessageEvent) string {
+	return event.Type + "/" + event.Message.ID
+}
+
+// reserve marks the event as being processed. It returns false if the event has been processed
+// within the duplicate window or it is being processed by a concurrent request.
+func (h *WebhookHandler) reserve(event *MessageEvent) bool {
+	if h.opts.DuplicateWindow <= 0 || event.Message.ID == "" {
+		return true
+	}
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+	key := eventKey(event)
+	if seen, ok := h.seen[key]; (ok && time.Since(seen) < h.opts.DuplicateWindow) || h.inFlight[key] {
+		return false
+	}
+	h.inFlight[key] = true
+	return true
+}
+
+// release ends the processing of the reserved event. Processed events are remembered,
+// failed ones can be delivered again.
+func (h *WebhookHandler) release(event *MessageEvent, processed bool) {
+	if h.opts.DuplicateWindow <= 0 || event.Message.ID == "" {
+		return
+	}
+	h.seenMu.Lock()
+	defer h.seenMu.Unlock()
+	key := eventKey(event)
+	delete(h.inFlight, key)
+	if !processed {
+		return
+	}
+	now := time.Now()
+	h.seen[key] = now
+	if now.Sub(h.pruned) < h.opts.DuplicateWindow {
+		return
+	}
+	for key, seen := range h.seen {
+		if now.Sub(seen) >= h.opts.DuplicateWindow {
+			delete(h.seen, key)
+		}
+	}
+	h.pruned = now
+}
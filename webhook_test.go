@@ -0,0 +1,216 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func postCallback(handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body)))
+	return recorder
+}
+
+func TestWebhookHandler(t *testing.T) {
+	handler, _ := NewWebhookHandler(WebhookOpts{})
+	var received, failed *MessageEvent
+	handler.Handle(MessageReceived, func(ctx context.Context, event *MessageEvent) error {
+		received = event
+		return nil
+	})
+	handler.Handle(MessageFailed, func(ctx context.Context, event *MessageEvent) error {
+		failed = event
+		return nil
+	})
+	recorder := postCallback(handler, `[
+		{
+			"type"        : "message-received",
+			"time"        : "2016-09-14T18:20:16Z",
+			"description" : "Incoming message received",
+			"to"          : "+12345678902",
+			"message"     : {
+				"id"            : "14762070468292kw2fuqty55yp2b2",
+				"time"          : "2016-09-14T18:20:16Z",
+				"to"            : ["+12345678902"],
+				"from"          : "+12345678901",
+				"text"          : "Hey, check this out!",
+				"applicationId" : "93de2206-9669-4e07-948d-329f4b722ee2",
+				"media"         : ["https://messaging.bandwidth.com/api/v2/users/123/media/demo.jpg"],
+				"owner"         : "+12345678902",
+				"direction"     : "in",
+				"segmentCount"  : 1
+			}
+		},
+		{
+			"type"        : "message-failed",
+			"time"        : "2016-09-14T18:20:17Z",
+			"description" : "forbidden to country",
+			"to"          : "+52345678903",
+			"errorCode"   : 4432,
+			"message"     : {
+				"id"        : "14762070468292kw2fuqty55yp2b3",
+				"from"      : "+12345678902",
+				"text"      : "Hello",
+				"direction" : "out",
+				"tag"       : "test message"
+			}
+		},
+		{
+			"type"        : "message-delivered",
+			"time"        : "2016-09-14T18:20:18Z",
+			"description" : "ok",
+			"to"          : "+12345678903",
+			"message"     : {"id": "14762070468292kw2fuqty55yp2b4"}
+		}
+	]`)
+	expect(t, recorder.Code, http.StatusOK)
+	expect(t, received.Message.ID, "14762070468292kw2fuqty55yp2b2")
+	expect(t, received.Message.From, "+12345678901")
+	expect(t, received.Message.Owner, "+12345678902")
+	expect(t, received.Message.Time.String(), "2016-09-14 18:20:16 +0000 UTC")
+	expect(t, len(received.Message.Media), 1)
+	expect(t, failed.ErrorCode, 4432)
+	expect(t, failed.Description, "forbidden to country")
+	expect(t, failed.Message.Tag, "test message")
+}
+
+func TestWebhookHandlerSingleEvent(t *testing.T) {
+	handler, _ := NewWebhookHandler(WebhookOpts{})
+	var delivered *MessageEvent
+	handler.Handle(MessageDelivered, func(ctx context.Context, event *MessageEvent) error {
+		delivered = event
+		return nil
+	})
+	recorder := postCallback(handler, `{"type": "message-delivered", "to": "+12345678903", "message": {"id": "1"}}`)
+	expect(t, recorder.Code, http.StatusOK)
+	expect(t, delivered.Message.ID, "1")
+}
+
+func TestWebhookHandlerFail(t *testing.T) {
+	handler, _ := NewWebhookHandler(WebhookOpts{})
+	handler.Handle(MessageReceived, func(ctx context.Context, event *MessageEvent) error {
+		return errors.New("some error")
+	})
+	expect(t, postCallback(handler, `invalid json`).Code, http.StatusBadRequest)
+	expect(t, postCallback(handler, `[{"type": "message-received"}]`).Code, http.StatusInternalServerError)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/callback", nil))
+	expect(t, recorder.Code, http.StatusMethodNotAllowed)
+}
+
+func TestWebhookHandlerAuth(t *testing.T) {
+	handler, err := NewWebhookHandler(WebhookOpts{UserName: "user", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `[{"type": "message-delivered", "message": {"id": "1"}}]`
+	expect(t, postCallback(handler, body).Code, http.StatusUnauthorized)
+
+	request := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	request.SetBasicAuth("user", "wrong")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	expect(t, recorder.Code, http.StatusUnauthorized)
+
+	request = httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	request.SetBasicAuth("user", "secret")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	expect(t, recorder.Code, http.StatusOK)
+}
+
+func TestWebhookHandlerAllowedNetworks(t *testing.T) {
+	handler, err := NewWebhookHandler(WebhookOpts{AllowedNetworks: []string{"10.0.0.0/8", "192.0.2.1"},
+		TrustedProxies: []string{"127.0.0.1", "172.16.0.0/12"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `[{"type": "message-delivered", "message": {"id": "1"}}]`
+	post := func(remoteAddr, forwardedFor string) int {
+		request := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+		request.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			request.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+	expect(t, post("192.0.2.1:1234", ""), http.StatusOK)
+	expect(t, post("10.1.2.3:1234", ""), http.StatusOK)
+	expect(t, post("192.0.2.2:1234", ""), http.StatusForbidden)
+	expect(t, post("127.0.0.1:1234", "10.1.2.3"), http.StatusOK)
+	expect(t, post("127.0.0.1:1234", "192.0.2.2, 10.1.2.3, 172.16.0.1"), http.StatusOK)
+	// the leftmost entries are set by the client
+	expect(t, post("127.0.0.1:1234", "10.1.2.3, 192.0.2.2"), http.StatusForbidden)
+	expect(t, post("127.0.0.1:1234", "invalid"), http.StatusForbidden)
+	// the header is ignored unless the request comes from a trusted proxy
+	expect(t, post("192.0.2.2:1234", "10.1.2.3"), http.StatusForbidden)
+	expect(t, post("10.1.2.3:1234", "192.0.2.2"), http.StatusOK)
+
+	_, err = NewWebhookHandler(WebhookOpts{AllowedNetworks: []string{"invalid"}})
+	if err == nil {
+		t.Error("Should fail here")
+	}
+	_, err = NewWebhookHandler(WebhookOpts{TrustedProxies: []string{"invalid"}})
+	if err == nil {
+		t.Error("Should fail here")
+	}
+	_, err = NewWebhookHandler(WebhookOpts{UserName: "user"})
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestWebhookHandlerDuplicates(t *testing.T) {
+	handler, _ := NewWebhookHandler(WebhookOpts{DuplicateWindow: time.Minute})
+	calls := 0
+	fail := true
+	handler.Handle(MessageDelivered, func(ctx context.Context, event *MessageEvent) error {
+		calls++
+		if fail {
+			fail = false
+			return errors.New("some error")
+		}
+		return nil
+	})
+	body := `[{"type": "message-delivered", "message": {"id": "1"}}]`
+	expect(t, postCallback(handler, body).Code, http.StatusInternalServerError)
+	expect(t, postCallback(handler, body).Code, http.StatusOK)
+	expect(t, postCallback(handler, body).Code, http.StatusOK)
+	expect(t, calls, 2)
+	expect(t, postCallback(handler, `[{"type": "message-delivered", "message": {"id": "2"}}]`).Code, http.StatusOK)
+	expect(t, calls, 3)
+}
+
+func TestWebhookHandlerConcurrentDuplicates(t *testing.T) {
+	handler, _ := NewWebhookHandler(WebhookOpts{DuplicateWindow: time.Minute})
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	var calls int32
+	handler.Handle(MessageDelivered, func(ctx context.Context, event *MessageEvent) error {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-finish
+		return nil
+	})
+	body := `[{"type": "message-delivered", "message": {"id": "1"}}]`
+	done := make(chan int)
+	go func() {
+		done <- postCallback(handler, body).Code
+	}()
+	<-started
+	// the retry arrives while the first delivery is still being handled
+	expect(t, postCallback(handler, body).Code, http.StatusOK)
+	close(finish)
+	expect(t, <-done, http.StatusOK)
+	expect(t, postCallback(handler, body).Code, http.StatusOK)
+	expect(t, atomic.LoadInt32(&calls), int32(1))
+}